@@ -3,10 +3,12 @@ package mythicbeasts
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 )
 
 // basicAuth encodes basic auth for use in the auth header.
@@ -30,7 +32,7 @@ func (c *Client) signIn(ctx context.Context) (*AuthResponse, error) {
 	req.Header.Add("Authorization", "Basic "+basicAuth(c.Auth.KeyID, c.Auth.Secret))
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
-	res, err := c.Do(req)
+	res, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
@@ -45,10 +47,20 @@ func (c *Client) signIn(ctx context.Context) (*AuthResponse, error) {
 	}
 
 	ar := AuthResponse{}
-	err = json.Unmarshal(body, &ar)
-	if err != nil {
+	if err := transport.Decode(c.strictDecoding, "/login", body, &ar); err != nil {
 		return nil, err
 	}
 
 	return &ar, nil
 }
+
+// SignOut clears the cached access token, so the next request signs in
+// again. It makes no request: the auth service has no documented way to
+// revoke a token, so the old token stays valid until it expires.
+func (c *Client) SignOut() {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.Token = ""
+	c.tokenExpiresIn = 0
+	c.tokenLastUsedAt = time.Time{}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -107,3 +108,45 @@ func TestSignIn_ServerBadJSONOrStatus(t *testing.T) {
 		t.Fatalf("error = %q, want %q", err.Error(), want)
 	}
 }
+
+func TestSignOut_SignsInAgain(t *testing.T) {
+	t.Parallel()
+	var logins, others int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			atomic.AddInt32(&logins, 1)
+			_, _ = w.Write([]byte(`{"access_token":"XYZ","token_type":"bearer","expires_in":300}`))
+			return
+		}
+		atomic.AddInt32(&others, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, _ := NewClient("keyid", "secret")
+	c.AuthURL = srv.URL
+
+	for range 2 {
+		if _, err := c.Get(context.Background(), srv.URL, "/resource"); err != nil {
+			t.Fatalf("get error: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&logins); got != 1 {
+		t.Fatalf("logins = %d, want 1", got)
+	}
+
+	c.SignOut()
+	if c.Token != "" {
+		t.Fatalf("token = %q, want it cleared", c.Token)
+	}
+	if got := atomic.LoadInt32(&logins) + atomic.LoadInt32(&others); got != 3 {
+		t.Fatalf("requests = %d, want SignOut to send none", got)
+	}
+
+	if _, err := c.Get(context.Background(), srv.URL, "/resource"); err != nil {
+		t.Fatalf("get error: %v", err)
+	}
+	if got := atomic.LoadInt32(&logins); got != 2 {
+		t.Fatalf("logins = %d, want 2", got)
+	}
+}
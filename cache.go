@@ -0,0 +1,74 @@
+package mythicbeasts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
+
+// Cache stores response bodies of catalogue endpoints such as
+// images, zones, products, disk sizes and Pi models.
+// Implementations must be safe for concurrent use.
+type Cache = transport.Cache
+
+// MemoryCache is an in-memory Cache.
+type MemoryCache = transport.MemoryCache
+
+// NewMemoryCache constructs an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return transport.NewMemoryCache()
+}
+
+// Cacheable marks the context so GET requests made with it may be
+// served from the client's cache, and are sent as conditional requests
+// when the API returned an ETag or Last-Modified header for them.
+// Catalogue methods of the services do this already; use it in
+// custom services for data that rarely changes.
+func Cacheable(ctx context.Context) context.Context {
+	return transport.Cacheable(ctx)
+}
+
+// WithCache serves repeated catalogue reads from the cache
+// for the ttl instead of sending them to the API.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// cached sends a GET request through the cache.
+// Only successful responses are stored.
+func (c *Client) cached(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	key := req.URL.String()
+	if body, ok := c.cache.Get(key); ok {
+		return &http.Response{
+			Status:     http.StatusText(http.StatusOK),
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+
+	res, err := send(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return res, nil
+	}
+
+	body, err := c.Body(res)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, body, c.cacheTTL)
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	return res, nil
+}
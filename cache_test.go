@@ -0,0 +1,79 @@
+package mythicbeasts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCache_ServesCacheableGets(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "", WithCache(NewMemoryCache(), time.Minute))
+	svc := NewBaseService(c, s.URL)
+
+	for range 3 {
+		var out map[string]bool
+		if _, _, err := svc.GetJSON(Cacheable(context.Background()), "/catalogue", &out, http.StatusOK); err != nil {
+			t.Fatalf("get error: %v", err)
+		}
+		if !out["ok"] {
+			t.Fatalf("out = %v", out)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("server calls = %d, want 1", got)
+	}
+
+	if _, _, err := svc.GetJSON(context.Background(), "/catalogue", nil); err != nil {
+		t.Fatalf("get error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("server calls = %d, want 2 for an uncacheable request", got)
+	}
+}
+
+func TestWithCache_SkipsErrors(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "", WithCache(NewMemoryCache(), time.Minute))
+	ctx := Cacheable(context.Background())
+	for range 2 {
+		res, err := c.Get(ctx, s.URL, "/catalogue")
+		if err != nil {
+			t.Fatalf("get error: %v", err)
+		}
+		_, _ = c.Body(res)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("server calls = %d, want 2", got)
+	}
+}
+
+func TestMemoryCache_Expires(t *testing.T) {
+	t.Parallel()
+	m := NewMemoryCache()
+	m.Set("k", []byte("v"), -time.Second)
+	if _, ok := m.Get("k"); ok {
+		t.Fatalf("expected expired entry to be missing")
+	}
+	m.Set("k", []byte("v"), time.Minute)
+	if v, ok := m.Get("k"); !ok || string(v) != "v" {
+		t.Fatalf("Get = %q, %v", v, ok)
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 	"github.com/paultibbetts/mythicbeasts-client-go/pi"
 	"github.com/paultibbetts/mythicbeasts-client-go/proxy"
 	"github.com/paultibbetts/mythicbeasts-client-go/vps"
@@ -42,6 +44,22 @@ type Client struct {
 	tokenExpiresIn  time.Duration
 	tokenLastUsedAt time.Time
 
+	rateMu    sync.RWMutex
+	rateLimit RateLimit
+	hasRate   bool
+
+	dryRun         bool
+	dryRunLogger   *slog.Logger
+	errorBodyLimit int
+	protectedVPS   []string
+	strictDecoding bool
+
+	cache    Cache
+	cacheTTL time.Duration
+
+	validMu    sync.RWMutex
+	validators map[string]validated
+
 	piService    *pi.Service
 	vpsService   *vps.Service
 	proxyService *proxy.Service
@@ -65,7 +83,8 @@ type AuthResponse struct {
 // and a token is fetched on the first authenticated request.
 // If they are empty it will return an unauthenticated client.
 // The returned client does not follow redirects.
-func NewClient(keyid, secret string) (*Client, error) {
+// Options are applied after the defaults are set.
+func NewClient(keyid, secret string, opts ...Option) (*Client, error) {
 	hc := &http.Client{
 		Timeout: 30 * time.Second,
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
@@ -79,13 +98,15 @@ func NewClient(keyid, secret string) (*Client, error) {
 		UserAgent:    DefaultUserAgent,
 	}
 
-	if keyid == "" || secret == "" {
-		return &c, nil
+	if keyid != "" && secret != "" {
+		c.Auth = AuthStruct{
+			KeyID:  keyid,
+			Secret: secret,
+		}
 	}
 
-	c.Auth = AuthStruct{
-		KeyID:  keyid,
-		Secret: secret,
+	for _, opt := range opts {
+		opt(&c)
 	}
 
 	return &c, nil
@@ -93,7 +114,23 @@ func NewClient(keyid, secret string) (*Client, error) {
 
 // Do sends the request with the configured client,
 // injecting the token if it is present.
+// In dry-run mode mutating requests are not sent and an *ErrDryRun is returned.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.dryRun && isMutating(req.Method) {
+		return nil, dryRunRequest(req, c.dryRunLogger)
+	}
+	if req.Method == http.MethodGet && transport.IsCacheable(req.Context()) {
+		if c.cache != nil {
+			return c.cached(req, c.conditional)
+		}
+		return c.conditional(req)
+	}
+
+	return c.authorize(req)
+}
+
+// authorize sends the request, injecting the token if it is present.
+func (c *Client) authorize(req *http.Request) (*http.Response, error) {
 	if req.Header.Get("Authorization") == "" {
 		token, err := c.ensureToken(req.Context())
 		if err != nil {
@@ -104,6 +141,12 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 			c.markTokenUsed()
 		}
 	}
+
+	return c.send(req)
+}
+
+// send sends the request without authentication or dry-run handling.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
 	if c.UserAgent != "" && req.Header.Get("User-Agent") == "" {
 		req.Header.Set("User-Agent", c.UserAgent)
 	}
@@ -112,6 +155,7 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.recordRateLimit(res)
 
 	return res, nil
 }
@@ -253,17 +297,22 @@ func (c *Client) Delete(ctx context.Context, baseURL, endpoint string) error {
 	case http.StatusNoContent, http.StatusOK, http.StatusAccepted, http.StatusNotFound:
 		return nil
 	default:
-		return fmt.Errorf("unexpected status %d: %s", res.StatusCode, truncateBody(body))
+		apiErr := transport.NewAPIError(res, body)
+		apiErr.Limit = c.errorBodyLimit
+		return apiErr
 	}
 }
 
-// truncateBody is a helper function to truncate the body of a response.
-func truncateBody(b []byte) string {
-	const max = 512
-	if len(b) <= max {
-		return string(b)
-	}
-	return string(b[:max]) + "..."
+// ErrorBodyLimit returns the number of bytes of a response body
+// included in APIError messages. See WithErrorBodyLimit.
+func (c *Client) ErrorBodyLimit() int {
+	return c.errorBodyLimit
+}
+
+// StrictDecoding reports whether API responses are decoded strictly.
+// See WithStrictDecoding.
+func (c *Client) StrictDecoding() bool {
+	return c.strictDecoding
 }
 
 // Body reads and closes the body of a response.
@@ -275,9 +324,17 @@ func (c *Client) Body(res *http.Response) ([]byte, error) {
 
 // PollProvisioning repeatedly polls the pollURL until completion, error
 // or timeout. It uses a check function to determine completion.
+// The wait between polls is opts.Interval, or PollInterval if it is
+// not set, and every 200, 202 and 303 response is passed to
+// opts.Observer, if it is set.
 // On success it returns the final resource URL.
-func (c *Client) PollProvisioning(ctx context.Context, baseURL, pollURL string, timeout time.Duration, identifier string, check func(map[string]any, string) (string, bool)) (serverURL string, error error) {
+func (c *Client) PollProvisioning(ctx context.Context, baseURL, pollURL string, timeout time.Duration, identifier string, opts PollOptions, check func(map[string]any, string) (string, bool)) (serverURL string, error error) {
 	deadline := time.Now().Add(timeout)
+	interval := c.PollInterval
+	if opts.Interval > 0 {
+		interval = opts.Interval
+	}
+	observe := opts.Observer
 
 	req, err := c.NewRequest(ctx, "GET", baseURL, pollURL, nil)
 	if err != nil {
@@ -289,7 +346,7 @@ func (c *Client) PollProvisioning(ctx context.Context, baseURL, pollURL string,
 			return "", err
 		}
 		if time.Now().After(deadline) {
-			return "", errors.New("timed out while provisioning")
+			return "", transport.ErrPollTimeout
 		}
 
 		res, err := c.Do(req)
@@ -303,6 +360,15 @@ func (c *Client) PollProvisioning(ctx context.Context, baseURL, pollURL string,
 
 		location := res.Header.Get("Location")
 
+		if observe != nil {
+			switch res.StatusCode {
+			case http.StatusOK, http.StatusAccepted, http.StatusSeeOther:
+				event := transport.PollEvent{Time: time.Now(), StatusCode: res.StatusCode}
+				_ = json.Unmarshal(body, &event.Data)
+				observe(event)
+			}
+		}
+
 		switch res.StatusCode {
 		case http.StatusSeeOther:
 			if location == "" {
@@ -310,7 +376,7 @@ func (c *Client) PollProvisioning(ctx context.Context, baseURL, pollURL string,
 			}
 			return location, nil
 		case http.StatusInternalServerError:
-			return "", fmt.Errorf("provisioning failed: %s", string(body))
+			return "", &transport.ErrPollFailed{Body: string(body)}
 		case http.StatusAccepted:
 			if location != "" {
 				return location, nil
@@ -318,7 +384,7 @@ func (c *Client) PollProvisioning(ctx context.Context, baseURL, pollURL string,
 			select {
 			case <-ctx.Done():
 				return "", ctx.Err()
-			case <-time.After(c.PollInterval):
+			case <-time.After(interval):
 				continue
 			}
 		case http.StatusOK:
@@ -339,7 +405,7 @@ func (c *Client) PollProvisioning(ctx context.Context, baseURL, pollURL string,
 			select {
 			case <-ctx.Done():
 				return "", ctx.Err()
-			case <-time.After(c.PollInterval):
+			case <-time.After(interval):
 				continue
 			}
 		default:
@@ -3,6 +3,7 @@ package mythicbeasts
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -304,7 +305,7 @@ func TestPoll_SeeOtherReturnsLocation(t *testing.T) {
 	c, _ := NewClient("", "")
 	c.PollInterval = time.Millisecond
 
-	url, err := c.PollProvisioning(context.Background(), s.URL, s.URL, 2*time.Second, "id", func(map[string]any, string) (string, bool) {
+	url, err := c.PollProvisioning(context.Background(), s.URL, s.URL, 2*time.Second, "id", PollOptions{}, func(map[string]any, string) (string, bool) {
 		return "", false
 	})
 	if err != nil {
@@ -325,7 +326,7 @@ func TestPoll_InternalServerError(t *testing.T) {
 	c, _ := NewClient("", "")
 	c.PollInterval = time.Millisecond
 
-	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", func(map[string]any, string) (string, bool) {
+	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", PollOptions{}, func(map[string]any, string) (string, bool) {
 		return "", false
 	})
 	if err == nil || err.Error() != "provisioning failed: boom" {
@@ -343,7 +344,7 @@ func TestPoll_AcceptedWithLocation(t *testing.T) {
 	c, _ := NewClient("", "")
 	c.PollInterval = time.Millisecond
 
-	url, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", func(map[string]any, string) (string, bool) {
+	url, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", PollOptions{}, func(map[string]any, string) (string, bool) {
 		return "", false
 	})
 	if err != nil {
@@ -354,6 +355,36 @@ func TestPoll_AcceptedWithLocation(t *testing.T) {
 	}
 }
 
+func TestPoll_OptionsObserverAndInterval(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(scriptHandler([]step{
+		{status: http.StatusAccepted},
+		{status: http.StatusSeeOther, headers: map[string]string{"Location": "/ready/123"}},
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "")
+	c.PollInterval = time.Hour
+
+	var statuses []int
+	opts := PollOptions{
+		Interval: time.Millisecond,
+		Observer: func(event PollEvent) { statuses = append(statuses, event.StatusCode) },
+	}
+	url, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", opts, func(map[string]any, string) (string, bool) {
+		return "", false
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if url != "/ready/123" {
+		t.Fatalf("url=%s, want /ready/123", url)
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusAccepted || statuses[1] != http.StatusSeeOther {
+		t.Fatalf("observed=%v, want [202 303]", statuses)
+	}
+}
+
 func TestPoll_OKWithCompletionChecker(t *testing.T) {
 	t.Parallel()
 	want := "https://srv/ok"
@@ -375,7 +406,7 @@ func TestPoll_OKWithCompletionChecker(t *testing.T) {
 		return "", false
 	}
 
-	url, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", checker)
+	url, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", PollOptions{}, checker)
 	if err != nil {
 		t.Fatalf("err: %v", err)
 	}
@@ -394,7 +425,7 @@ func TestPoll_Timeout(t *testing.T) {
 	c, _ := NewClient("", "")
 	c.PollInterval = 5 * time.Millisecond
 
-	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, 20*time.Millisecond, "id", func(map[string]any, string) (string, bool) {
+	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, 20*time.Millisecond, "id", PollOptions{}, func(map[string]any, string) (string, bool) {
 		return "", false
 	})
 	if err == nil || err.Error() != "timed out while provisioning" {
@@ -412,7 +443,7 @@ func TestPoll_OKBadJSON(t *testing.T) {
 	c, _ := NewClient("", "")
 	c.PollInterval = time.Millisecond
 
-	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", func(map[string]any, string) (string, bool) {
+	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", PollOptions{}, func(map[string]any, string) (string, bool) {
 		return "", false
 	})
 	if err == nil {
@@ -430,7 +461,7 @@ func TestPoll_UnexpectedStatus(t *testing.T) {
 	c, _ := NewClient("", "")
 	c.PollInterval = time.Millisecond
 
-	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", func(map[string]any, string) (string, bool) {
+	_, err := c.PollProvisioning(context.Background(), s.URL, s.URL, time.Second, "id", PollOptions{}, func(map[string]any, string) (string, bool) {
 		return "", false
 	})
 
@@ -438,3 +469,88 @@ func TestPoll_UnexpectedStatus(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestDelete_TruncatesErrorMessageKeepsBody(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("x", 600)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(long))
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "")
+	err := c.Delete(context.Background(), s.URL, "/")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.Body != long {
+		t.Fatalf("body length = %d, want %d", len(apiErr.Body), len(long))
+	}
+	if want := "unexpected status 400: " + long[:512] + "..."; err.Error() != want {
+		t.Fatalf("error message length = %d, want %d", len(err.Error()), len(want))
+	}
+}
+
+func TestGetJSON_ErrorMessageKeepsFullBody(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("x", 600)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(long))
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "")
+	svc := NewBaseService(c, s.URL)
+
+	_, _, err := svc.GetJSON(context.Background(), "/", nil, http.StatusOK)
+	if want := "unexpected status 400: " + long; err == nil || err.Error() != want {
+		t.Fatalf("err = %v, want the complete body", err)
+	}
+}
+
+func TestWithErrorBodyLimit(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "", WithErrorBodyLimit(4))
+	svc := NewBaseService(c, s.URL)
+
+	_, _, err := svc.GetJSON(context.Background(), "/", nil, http.StatusOK)
+	if err == nil || err.Error() != "unexpected status 400: 0123..." {
+		t.Fatalf("err = %v, want truncated message", err)
+	}
+
+	if err := (&APIError{StatusCode: 400, Body: "abcdé!", Limit: 5}).Error(); err != "unexpected status 400: abcd..." {
+		t.Fatalf("err = %q, want the cut before the split character", err)
+	}
+
+	c, _ = NewClient("", "", WithErrorBodyLimit(-1))
+	if err := c.Delete(context.Background(), s.URL, "/"); err == nil || err.Error() != "unexpected status 400: 0123456789" {
+		t.Fatalf("err = %v, want untruncated message", err)
+	}
+}
+
+func TestWithProtectedVPS(t *testing.T) {
+	t.Parallel()
+	c, err := NewClient("id", "secret", WithProtectedVPS("prod-*"))
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	if _, ok := c.VPS().IsProtected("prod-web"); !ok {
+		t.Fatalf("prod-web should be protected")
+	}
+
+	c.VPS().Protected[0] = "staging-*"
+	if c.protectedVPS[0] != "prod-*" {
+		t.Fatalf("client patterns=%v, want them unchanged by the service", c.protectedVPS)
+	}
+}
@@ -0,0 +1,71 @@
+package mythicbeasts
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// validated holds a response body and the validators the API sent with it.
+type validated struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// conditional sends a GET request with If-None-Match and
+// If-Modified-Since headers when an earlier response carried an
+// ETag or Last-Modified header, returning the stored body on a 304.
+func (c *Client) conditional(req *http.Request) (*http.Response, error) {
+	key := req.URL.String()
+
+	c.validMu.RLock()
+	prev, hasPrev := c.validators[key]
+	c.validMu.RUnlock()
+
+	if hasPrev {
+		if prev.etag != "" {
+			req.Header.Set("If-None-Match", prev.etag)
+		}
+		if prev.lastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.lastModified)
+		}
+	}
+
+	res, err := c.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotModified && hasPrev:
+		_, _ = c.Body(res)
+		res.StatusCode = http.StatusOK
+		res.Status = http.StatusText(http.StatusOK)
+		res.Body = io.NopCloser(bytes.NewReader(prev.body))
+		return res, nil
+	case res.StatusCode != http.StatusOK:
+		return res, nil
+	}
+
+	etag := res.Header.Get("ETag")
+	lastModified := res.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return res, nil
+	}
+
+	body, err := c.Body(res)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.validMu.Lock()
+	defer c.validMu.Unlock()
+	if c.validators == nil {
+		c.validators = map[string]validated{}
+	}
+	c.validators[key] = validated{etag: etag, lastModified: lastModified, body: body}
+
+	return res, nil
+}
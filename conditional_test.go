@@ -0,0 +1,71 @@
+package mythicbeasts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConditional_ReturnsStoredBodyOnNotModified(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if calls > 1 {
+			t.Fatalf("second request missing If-None-Match")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"name":"debian"}`))
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "")
+	svc := NewBaseService(c, s.URL)
+	ctx := Cacheable(context.Background())
+
+	for range 2 {
+		var out struct {
+			Name string `json:"name"`
+		}
+		res, _, err := svc.GetJSON(ctx, "/vps/images", &out, http.StatusOK)
+		if err != nil {
+			t.Fatalf("get error: %v", err)
+		}
+		if res.StatusCode != http.StatusOK || out.Name != "debian" {
+			t.Fatalf("status=%d out=%+v", res.StatusCode, out)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestConditional_SendsIfModifiedSince(t *testing.T) {
+	t.Parallel()
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	var got string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("If-Modified-Since")
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "")
+	ctx := Cacheable(context.Background())
+	for range 2 {
+		res, err := c.Get(ctx, s.URL, "/vps/zones")
+		if err != nil {
+			t.Fatalf("get error: %v", err)
+		}
+		_, _ = c.Body(res)
+	}
+	if got != lastModified {
+		t.Fatalf("If-Modified-Since = %q, want %q", got, lastModified)
+	}
+}
@@ -13,5 +13,10 @@ An API key is required for authentication. One can be obtained from https://www.
 	ctx := context.Background()
 
 	images, err := c.VPS().GetImages(ctx)
+
+Each API is reached through its own service, returned by Client.Pi,
+Client.VPS and Client.Proxy; the Client itself has no Pi, VPS or Proxy
+methods. For example, Pis are provisioned with pi.Service.Create and
+listed, with their identifiers, by pi.Service.List.
 */
 package mythicbeasts
@@ -0,0 +1,120 @@
+package mythicbeasts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ErrDryRun is returned in dry-run mode instead of sending a mutating request.
+// It describes the request that would have been sent.
+type ErrDryRun struct {
+	Method string
+	URL    string
+	Body   []byte
+}
+
+func (e *ErrDryRun) Error() string {
+	return fmt.Sprintf("dry run: %s %s not sent", e.Method, e.URL)
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// dryRunSecretFields are the JSON fields whose values are replaced
+// before a dry-run request body is logged.
+var dryRunSecretFields = map[string]bool{
+	"password":         true,
+	"root_password":    true,
+	"secret":           true,
+	"ssh_key":          true,
+	"ssh_keys":         true,
+	"token":            true,
+	"user_data":        true,
+	"user_data_string": true,
+}
+
+// dryRunRedacted replaces dry-run secret fields in a logged body.
+const dryRunRedacted = "REDACTED"
+
+// dryRunRequest validates a request that will not be sent and, if logger
+// is not nil, logs it with secret fields redacted.
+func dryRunRequest(req *http.Request, logger *slog.Logger) error {
+	var body []byte
+	if req.Body != nil {
+		defer req.Body.Close()
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return err
+		}
+		body = b
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "application/json" && !json.Valid(body) {
+		return fmt.Errorf("dry run: %s %s has an invalid JSON body", req.Method, req.URL)
+	}
+
+	if logger != nil {
+		logger.LogAttrs(req.Context(), slog.LevelInfo, "dry run",
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.String("body", redactBody(mediaType, body)),
+		)
+	}
+
+	return &ErrDryRun{Method: req.Method, URL: req.URL.String(), Body: body}
+}
+
+// redactBody returns body for logging. Secret fields of a JSON body are
+// replaced, and any other non-empty body is omitted since it cannot be
+// checked for secrets.
+func redactBody(mediaType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if mediaType != "application/json" {
+		return fmt.Sprintf("<%d bytes omitted>", len(body))
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes omitted>", len(body))
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes omitted>", len(body))
+	}
+
+	return string(b)
+}
+
+// redactValue replaces the values of secret fields anywhere in v.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if dryRunSecretFields[strings.ToLower(k)] {
+				v[k] = dryRunRedacted
+				continue
+			}
+			v[k] = redactValue(field)
+		}
+	case []any:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+
+	return v
+}
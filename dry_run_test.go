@@ -0,0 +1,115 @@
+package mythicbeasts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDryRun_InterceptsMutatingRequests(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "", WithDryRun())
+
+	req, _ := c.NewRequest(context.Background(), http.MethodPost, s.URL, "/vps/servers/a", strings.NewReader(`{"product":"VPS-1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	_, err := c.Do(req)
+
+	var dryErr *ErrDryRun
+	if !errors.As(err, &dryErr) {
+		t.Fatalf("err = %v, want *ErrDryRun", err)
+	}
+	if dryErr.Method != http.MethodPost || string(dryErr.Body) != `{"product":"VPS-1"}` {
+		t.Fatalf("dry run = %+v", dryErr)
+	}
+
+	if err := c.Delete(context.Background(), s.URL, "/vps/servers/a"); !errors.As(err, &dryErr) {
+		t.Fatalf("delete err = %v, want *ErrDryRun", err)
+	}
+
+	if _, err := c.Get(context.Background(), s.URL, "/vps/servers/a"); err != nil {
+		t.Fatalf("get error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("server calls = %d, want 1", got)
+	}
+}
+
+func TestDryRun_InvalidJSON(t *testing.T) {
+	t.Parallel()
+	c, _ := NewClient("", "", WithDryRun())
+
+	req, _ := c.NewRequest(context.Background(), http.MethodPatch, "https://example.com", "/x", strings.NewReader(`{bad`))
+	req.Header.Set("Content-Type", "application/json")
+	_, err := c.Do(req)
+
+	var dryErr *ErrDryRun
+	if err == nil || errors.As(err, &dryErr) {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+}
+
+func TestDryRun_SignsIn(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			_, _ = w.Write([]byte(`{"access_token":"XYZ","token_type":"bearer"}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("keyid", "secret", WithDryRun())
+	c.AuthURL = s.URL
+
+	if _, err := c.Get(context.Background(), s.URL, "/resource"); err != nil {
+		t.Fatalf("get error: %v", err)
+	}
+	if c.Token != "XYZ" {
+		t.Fatalf("token = %q, want XYZ", c.Token)
+	}
+}
+
+func TestDryRun_LogsRedactedBody(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	c, _ := NewClient("", "", WithDryRun(slog.New(slog.NewTextHandler(&buf, nil))))
+
+	body := `{"product":"VPS-1","root_password":"hunter2","user_data_string":"#cloud-config","nested":{"ssh_keys":"ssh-ed25519 AAAA"}}`
+	req, _ := c.NewRequest(context.Background(), http.MethodPost, "https://example.com", "/vps/servers/a", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	_, err := c.Do(req)
+
+	var dryErr *ErrDryRun
+	if !errors.As(err, &dryErr) {
+		t.Fatalf("err = %v, want *ErrDryRun", err)
+	}
+	if string(dryErr.Body) != body {
+		t.Fatalf("body = %s, want %s", dryErr.Body, body)
+	}
+
+	logged := buf.String()
+	for _, secret := range []string{"hunter2", "#cloud-config", "AAAA"} {
+		if strings.Contains(logged, secret) {
+			t.Fatalf("log %q contains secret %q", logged, secret)
+		}
+	}
+	if !strings.Contains(logged, "VPS-1") || !strings.Contains(logged, "/vps/servers/a") {
+		t.Fatalf("log = %q, want method, url and product", logged)
+	}
+}
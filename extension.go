@@ -0,0 +1,36 @@
+package mythicbeasts
+
+import (
+	"net/http"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
+
+// Requester provides the shared transport operations used by service clients.
+// *Client implements Requester, so custom services built on BaseService
+// reuse its authentication, token refresh and provisioning polling.
+type Requester = transport.Requester
+
+// BaseService holds shared transport state for service clients.
+// Embed it in a struct to build a client for an endpoint this
+// library does not cover yet.
+type BaseService = transport.BaseService
+
+// PollOptions controls a single Requester.PollProvisioning call.
+type PollOptions = transport.PollOptions
+
+// PollEvent describes a single response received while polling
+// a provisioning URL. See PollOptions.Observer.
+type PollEvent = transport.PollEvent
+
+var _ Requester = (*Client)(nil)
+
+// NewBaseService constructs a BaseService for the given client and base URL.
+func NewBaseService(client Requester, baseURL string) BaseService {
+	return transport.NewBaseService(client, baseURL)
+}
+
+// ExpectStatus returns an *APIError if the response status code is not allowed.
+func ExpectStatus(res *http.Response, body []byte, allowedStatus ...int) error {
+	return transport.ExpectStatus(res, body, allowedStatus...)
+}
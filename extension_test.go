@@ -0,0 +1,61 @@
+package mythicbeasts_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/paultibbetts/mythicbeasts-client-go"
+)
+
+type widgetService struct {
+	mythicbeasts.BaseService
+}
+
+type widget struct {
+	Name string `json:"name"`
+}
+
+func (s *widgetService) Get(ctx context.Context, name string) (widget, error) {
+	var result widget
+	if _, _, err := s.GetJSON(ctx, "/widgets/"+name, &result, http.StatusOK); err != nil {
+		return widget{}, err
+	}
+	return result, nil
+}
+
+func TestBaseService_CustomService(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/widgets/gear", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Fatalf("Authorization = %q, want %q", got, "Bearer tok")
+		}
+		_, _ = w.Write([]byte(`{"name":"gear"}`))
+	})
+	mux.HandleFunc("/widgets/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	c, _ := mythicbeasts.NewClient("", "")
+	c.Token = "tok"
+	svc := &widgetService{BaseService: mythicbeasts.NewBaseService(c, srv.URL)}
+
+	got, err := svc.Get(context.Background(), "gear")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if got.Name != "gear" {
+		t.Fatalf("name = %q, want gear", got.Name)
+	}
+
+	_, err = svc.Get(context.Background(), "missing")
+	var apiErr *mythicbeasts.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want 404 APIError", err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package batch runs indexed operations concurrently for the
+// service helpers that act on several servers at once.
+package batch
+
+import (
+	"context"
+	"sync"
+)
+
+// ForEach calls fn for each index in [0, n) with at most concurrency
+// calls running at once. With failFast set, indexes not yet started
+// after fn returns an error are passed to skip instead.
+// A concurrency below 1 runs one call at a time.
+func ForEach(ctx context.Context, n, concurrency int, failFast bool, fn func(ctx context.Context, i int) error, skip func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+		sem    = make(chan struct{}, concurrency)
+	)
+
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+
+		mu.Lock()
+		stop := failed && failFast
+		mu.Unlock()
+		if stop {
+			<-sem
+			skip(i)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, i); err != nil {
+				mu.Lock()
+				failed = true
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
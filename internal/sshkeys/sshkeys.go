@@ -0,0 +1,133 @@
+// Package sshkeys parses and validates OpenSSH public keys
+// as used in authorized_keys files.
+package sshkeys
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// knownTypes are the public key algorithms accepted by Validate.
+var knownTypes = map[string]bool{
+	"ssh-rsa":                            true,
+	"ssh-dss":                            true,
+	"ssh-ed25519":                        true,
+	"ecdsa-sha2-nistp256":                true,
+	"ecdsa-sha2-nistp384":                true,
+	"ecdsa-sha2-nistp521":                true,
+	"sk-ssh-ed25519@openssh.com":         true,
+	"sk-ecdsa-sha2-nistp256@openssh.com": true,
+}
+
+// Keys is a list of OpenSSH public keys, one per entry,
+// in "<type> <base64> [comment]" form.
+type Keys []string
+
+// Parse splits a newline-separated list of public keys,
+// skipping blank lines and # comments, and validates every key.
+// The parsed keys are returned even if some are invalid.
+func Parse(s string) (Keys, error) {
+	var keys Keys
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	return keys, keys.Validate()
+}
+
+// Validate checks that every key is a well-formed public key,
+// returning an error for each malformed key joined with errors.Join.
+func (k Keys) Validate() error {
+	var errs []error
+	for i, key := range k {
+		if err := validate(key); err != nil {
+			errs = append(errs, fmt.Errorf("ssh key %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dedupe returns the keys with duplicates removed, keeping the first.
+// Keys are compared by type and key data, ignoring comments.
+func (k Keys) Dedupe() Keys {
+	seen := make(map[string]bool, len(k))
+	var result Keys
+	for _, key := range k {
+		id := identity(key)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, key)
+	}
+	return result
+}
+
+// Equal reports whether k and other hold the same keys, in any order.
+// Keys are compared by type and key data, ignoring comments and duplicates.
+func (k Keys) Equal(other Keys) bool {
+	a, b := make(map[string]bool, len(k)), make(map[string]bool, len(other))
+	for _, key := range k {
+		a[identity(key)] = true
+	}
+	for _, key := range other {
+		b[identity(key)] = true
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for id := range a {
+		if !b[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the keys joined by newlines, as sent to the API.
+func (k Keys) String() string {
+	return strings.Join(k, "\n")
+}
+
+// identity returns the type and key data of key.
+func identity(key string) string {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return strings.TrimSpace(key)
+	}
+	return fields[0] + " " + fields[1]
+}
+
+func validate(key string) error {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return errors.New("expected \"<type> <base64> [comment]\"")
+	}
+
+	keyType := fields[0]
+	if !knownTypes[keyType] {
+		return fmt.Errorf("unknown key type %q", keyType)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return fmt.Errorf("key data is not valid base64: %w", err)
+	}
+
+	// the key data starts with the length-prefixed key type
+	if len(blob) < 4 {
+		return errors.New("key data is too short")
+	}
+	n := binary.BigEndian.Uint32(blob)
+	if uint64(len(blob)-4) < uint64(n) || string(blob[4:4+n]) != keyType {
+		return fmt.Errorf("key data does not match key type %q", keyType)
+	}
+
+	return nil
+}
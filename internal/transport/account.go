@@ -0,0 +1,76 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrPaymentRequired indicates the account does not have the credit
+// or payment details to pay for the request. It wraps the *APIError.
+type ErrPaymentRequired struct {
+	// Message is the reason given by the API.
+	Message string
+	Err     error
+}
+
+func (e *ErrPaymentRequired) Error() string {
+	return fmt.Sprintf("payment required: %s", e.Message)
+}
+
+func (e *ErrPaymentRequired) Unwrap() error { return e.Err }
+
+// ErrQuotaExceeded indicates the request would take the account
+// over one of its limits. It wraps the *APIError.
+type ErrQuotaExceeded struct {
+	// Message is the reason given by the API.
+	Message string
+	Err     error
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("quota exceeded: %s", e.Message)
+}
+
+func (e *ErrQuotaExceeded) Unwrap() error { return e.Err }
+
+// paymentWords are found in 403 messages about unpaid balances.
+var paymentWords = []string{"credit", "balance", "unpaid", "payment", "funds"}
+
+// quotaWords are found in 403 messages about account limits.
+var quotaWords = []string{"limit", "quota", "maximum", "exceed"}
+
+// AccountError maps payment and account limit failures to
+// ErrPaymentRequired and ErrQuotaExceeded. A 402 is always a payment
+// failure; a 403 is one if its message mentions credit or an unpaid
+// balance, and is an exceeded quota if it mentions a limit or quota.
+// Other errors, including a 403 for an API key without permission,
+// are returned unchanged.
+func AccountError(err *APIError) error {
+	switch err.StatusCode {
+	case http.StatusPaymentRequired:
+		return &ErrPaymentRequired{Message: err.Message(), Err: err}
+	case http.StatusForbidden:
+		msg := err.Message()
+		switch {
+		case mentions(msg, paymentWords):
+			return &ErrPaymentRequired{Message: msg, Err: err}
+		case mentions(msg, quotaWords):
+			return &ErrQuotaExceeded{Message: msg, Err: err}
+		}
+		return err
+	default:
+		return err
+	}
+}
+
+// mentions reports whether msg contains any of words, ignoring case.
+func mentions(msg string, words []string) bool {
+	lower := strings.ToLower(msg)
+	for _, word := range words {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache stores response bodies of catalogue endpoints.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, if it has not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value for key for the ttl.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// MemoryCache is an in-memory Cache.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCache constructs an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]cacheEntry{}}
+}
+
+// Get returns the value stored for key, if it has not expired.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores value for key for the ttl.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = cacheEntry{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+type cacheableKey struct{}
+
+// Cacheable marks the context so GET requests made with it
+// may be served from the client's cache.
+func Cacheable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheableKey{}, true)
+}
+
+// IsCacheable reports whether the context was marked with Cacheable.
+func IsCacheable(ctx context.Context) bool {
+	v, _ := ctx.Value(cacheableKey{}).(bool)
+	return v
+}
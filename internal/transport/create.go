@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrExists is returned by PostCreate when a retry finds that
+// the resource was already created.
+var ErrExists = errors.New("resource already exists")
+
+// RetryPolicy controls retries of provisioning requests.
+// The zero value makes a single attempt.
+type RetryPolicy struct {
+	// Attempts is the maximum number of requests to make.
+	Attempts int
+	// Backoff is the wait before the first retry. It doubles on each retry.
+	Backoff time.Duration
+}
+
+// PostCreate issues a provisioning POST with a JSON body to the endpoint.
+//
+// Blind retries of a POST could provision twice, so on a retryable failure
+// (a transport error or a 502 or 504 response) it first issues a GET to
+// the same endpoint. If the resource exists it returns ErrExists, otherwise
+// the POST is retried until the policy's attempts are used up.
+//
+// A 503 is not retried, since the Pi API uses it to report that no
+// servers of the requested specification are available.
+//
+// The response and body of the final POST are returned.
+func (s BaseService) PostCreate(ctx context.Context, endpoint string, in any, policy RetryPolicy) (*http.Response, []byte, error) {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attempts := max(policy.Attempts, 1)
+	backoff := policy.Backoff
+
+	for attempt := 1; ; attempt++ {
+		res, body, err := s.post(ctx, endpoint, payload)
+		if attempt >= attempts || !retryable(ctx, res, err) {
+			return res, body, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+
+		exists, checkErr := s.exists(ctx, endpoint)
+		if checkErr != nil {
+			return res, body, err
+		}
+		if exists {
+			return nil, nil, ErrExists
+		}
+	}
+}
+
+func (s BaseService) post(ctx context.Context, endpoint string, payload []byte) (*http.Response, []byte, error) {
+	req, err := s.NewRequest(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.Body(res)
+	if err != nil {
+		return res, nil, err
+	}
+
+	return res, body, nil
+}
+
+// exists reports whether a GET of the endpoint finds the resource.
+func (s BaseService) exists(ctx context.Context, endpoint string) (bool, error) {
+	res, err := s.Get(ctx, endpoint)
+	if err != nil {
+		return false, err
+	}
+	body, err := s.Body(res)
+	if err != nil {
+		return false, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, s.NewAPIError(res, body)
+	}
+}
+
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var urlErr *url.Error
+		return res == nil && errors.As(err, &urlErr)
+	}
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,184 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrMalformedResponse indicates the API response body did not contain
+// the expected structure or field types. Decode returns it in strict
+// mode when a response does not match the type it is decoded into.
+type ErrMalformedResponse struct {
+	// Resource is what was being decoded, e.g. "user_data",
+	// or the endpoint that sent the response.
+	Resource string
+	// Field is the path of the offending field, e.g. "specs.disk_size",
+	// if the problem is with a single field.
+	Field string
+	// Reason describes the problem.
+	Reason string
+	// Err is the underlying error, if there is one.
+	Err error
+}
+
+func (e *ErrMalformedResponse) Error() string {
+	resource := e.Resource
+	if resource == "" {
+		resource = "response"
+	}
+	if e.Field == "" && e.Reason == "" {
+		return fmt.Sprintf("malformed %s", resource)
+	}
+	if e.Reason == "" {
+		return fmt.Sprintf("malformed %s field %q", resource, e.Field)
+	}
+	if e.Field == "" {
+		return fmt.Sprintf("malformed %s: %s", resource, e.Reason)
+	}
+	return fmt.Sprintf("malformed %s field %q: %s", resource, e.Field, e.Reason)
+}
+
+func (e *ErrMalformedResponse) Unwrap() error { return e.Err }
+
+// errUnknownField is the Err of an ErrMalformedResponse for a field
+// the response type does not have.
+var errUnknownField = errors.New("unknown field")
+
+// strictDecoder is implemented by requesters that can require
+// responses to match their types exactly.
+type strictDecoder interface {
+	StrictDecoding() bool
+}
+
+// Decode unmarshals a response body from endpoint into out, strictly
+// if the client asks for strict decoding. GetJSON and DoJSON use it;
+// call it directly for responses read with Get or Do.
+func (s BaseService) Decode(endpoint string, body []byte, out any) error {
+	strict, ok := s.Client.(strictDecoder)
+	return Decode(ok && strict.StrictDecoding(), endpoint, body, out)
+}
+
+// Decode unmarshals body into out. With strict set, fields out does
+// not have and values of the wrong type are reported as
+// ErrMalformedResponse. Every field matches when out is a map, so
+// responses decoded into maps, such as provisioning polls, are only
+// checked for being valid JSON.
+func Decode(strict bool, endpoint string, body []byte, out any) error {
+	if !strict {
+		return json.Unmarshal(body, out)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		malformed := &ErrMalformedResponse{Resource: endpoint, Reason: err.Error(), Err: err}
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+			malformed.Field = typeErr.Field
+		}
+		return malformed
+	}
+
+	var data any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return &ErrMalformedResponse{Resource: endpoint, Reason: err.Error(), Err: err}
+	}
+	if field := unknownField(data, reflect.TypeOf(out), ""); field != "" {
+		return &ErrMalformedResponse{Resource: endpoint, Field: field, Reason: errUnknownField.Error(), Err: errUnknownField}
+	}
+
+	return nil
+}
+
+// unknownField returns the path of the first field in data that
+// has no matching field in t, or "" if every field matches.
+// Unlike json.Decoder.DisallowUnknownFields it also checks types
+// with their own UnmarshalJSON method.
+func unknownField(data any, t reflect.Type, path string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := data.(map[string]any)
+		if !ok {
+			return ""
+		}
+		fields := jsonFields(t)
+		for key, value := range object {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				return joinPath(path, key)
+			}
+			if found := unknownField(value, field, joinPath(path, key)); found != "" {
+				return found
+			}
+		}
+	case reflect.Map:
+		object, ok := data.(map[string]any)
+		if !ok {
+			return ""
+		}
+		for key, value := range object {
+			if found := unknownField(value, t.Elem(), joinPath(path, key)); found != "" {
+				return found
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := data.([]any)
+		if !ok {
+			return ""
+		}
+		for i, value := range items {
+			if found := unknownField(value, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); found != "" {
+				return found
+			}
+		}
+	}
+
+	return ""
+}
+
+// jsonFields maps the lower case JSON names of the fields of struct
+// type t, including promoted fields, to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range jsonFields(embedded) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultErrorBodyLimit is the number of bytes of the response body
+// included in an APIError message when no limit is set.
+const DefaultErrorBodyLimit = 512
+
+// APIError is returned when the API responds with an unexpected status.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Body is the complete response body.
+	Body string
+	// RateLimit holds any rate limit headers sent with the response.
+	RateLimit RateLimit
+	// Limit is the number of bytes of Body included in the message.
+	// Zero uses DefaultErrorBodyLimit and a negative value disables truncation.
+	Limit int
+}
+
+// NewAPIError constructs an APIError from a response and its body.
+func NewAPIError(res *http.Response, body []byte) *APIError {
+	e := &APIError{StatusCode: res.StatusCode, Body: string(body)}
+	if rl, ok := ParseRateLimit(res.Header); ok {
+		e.RateLimit = rl
+	}
+	return e
+}
+
+// Error includes at most Limit bytes of the body, cut at a UTF-8
+// character boundary and followed by "..." if anything was cut.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, truncateBody(e.Body, e.Limit))
+}
+
+// Message returns the "error" or "message" field of a JSON
+// error body, or the trimmed body if it has neither.
+func (e *APIError) Message() string {
+	var parsed struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(e.Body), &parsed); err == nil {
+		if parsed.Error != "" {
+			return parsed.Error
+		}
+		if parsed.Message != "" {
+			return parsed.Message
+		}
+	}
+	return strings.TrimSpace(e.Body)
+}
+
+// errorBodyLimiter is implemented by requesters that configure
+// the truncation of APIError messages.
+type errorBodyLimiter interface {
+	ErrorBodyLimit() int
+}
+
+// NewAPIError constructs an APIError using the error body limit of the
+// client. If the client sets no limit, the message includes the complete body.
+func (s BaseService) NewAPIError(res *http.Response, body []byte) *APIError {
+	e := NewAPIError(res, body)
+	e.Limit = -1
+	if l, ok := s.Client.(errorBodyLimiter); ok && l.ErrorBodyLimit() != 0 {
+		e.Limit = l.ErrorBodyLimit()
+	}
+	return e
+}
+
+// truncateBody is a helper function to truncate the body of a response
+// to at most limit bytes. It cuts at the start of a UTF-8 character so
+// a multi-byte character is never split.
+func truncateBody(b string, limit int) string {
+	if limit == 0 {
+		limit = DefaultErrorBodyLimit
+	}
+	if limit < 0 || len(b) <= limit {
+		return b
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(b[cut]) {
+		cut--
+	}
+	return b[:cut] + "..."
+}
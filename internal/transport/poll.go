@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPollTimeout is returned by PollProvisioning when the
+// provisioning job does not finish before the timeout.
+var ErrPollTimeout = errors.New("timed out while provisioning")
+
+// ErrPollFailed is returned by PollProvisioning when the
+// provisioning job reports that it failed.
+type ErrPollFailed struct {
+	// Body is the body of the failed poll response.
+	Body string
+}
+
+func (e *ErrPollFailed) Error() string {
+	return fmt.Sprintf("provisioning failed: %s", e.Body)
+}
+
+// PollEvent describes a single response received while polling
+// a provisioning URL.
+type PollEvent struct {
+	// Time is when the response was received.
+	Time time.Time
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Data is the decoded JSON body, if it had one.
+	Data map[string]any
+}
+
+// PollOptions controls a single PollProvisioning call.
+type PollOptions struct {
+	// Interval is the wait between polls.
+	// If Interval <= 0, the client's poll interval is used.
+	Interval time.Duration
+	// Observer, if set, is called with every 200, 202 and 303
+	// response received while polling.
+	Observer func(PollEvent)
+}
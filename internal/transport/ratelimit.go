@@ -0,0 +1,75 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit holds the rate limit state reported by the API.
+// Fields are zero when the corresponding header was not sent.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int64
+	// Remaining is the number of requests left in the current window.
+	Remaining int64
+	// Reset is when the current window resets.
+	Reset time.Time
+	// RetryAfter is how long the API asked the client to wait.
+	RetryAfter time.Duration
+	// ObservedAt is when the headers were received.
+	ObservedAt time.Time
+}
+
+// epochThreshold separates X-RateLimit-Reset values sent as a unix
+// timestamp from those sent as a number of seconds.
+const epochThreshold = 1_000_000_000
+
+// ParseRateLimit reads the X-RateLimit-* and Retry-After headers.
+// It reports false if none of them were present.
+func ParseRateLimit(h http.Header) (RateLimit, bool) {
+	now := time.Now()
+	rl := RateLimit{ObservedAt: now}
+	found := false
+
+	if n, ok := headerInt(h, "X-RateLimit-Limit"); ok {
+		rl.Limit = n
+		found = true
+	}
+	if n, ok := headerInt(h, "X-RateLimit-Remaining"); ok {
+		rl.Remaining = n
+		found = true
+	}
+	if n, ok := headerInt(h, "X-RateLimit-Reset"); ok {
+		if n >= epochThreshold {
+			rl.Reset = time.Unix(n, 0)
+		} else {
+			rl.Reset = now.Add(time.Duration(n) * time.Second)
+		}
+		found = true
+	}
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			rl.RetryAfter = time.Duration(secs) * time.Second
+			found = true
+		} else if at, err := http.ParseTime(v); err == nil {
+			rl.RetryAfter = max(at.Sub(now), 0)
+			found = true
+		}
+	}
+
+	return rl, found
+}
+
+func headerInt(h http.Header, key string) (int64, bool) {
+	v := strings.TrimSpace(h.Get(key))
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
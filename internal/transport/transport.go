@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"slices"
@@ -18,7 +17,7 @@ type Requester interface {
 	Get(ctx context.Context, baseURL string, endpoint string) (*http.Response, error)
 	Delete(ctx context.Context, baseURL string, endpoint string) error
 	Body(res *http.Response) ([]byte, error)
-	PollProvisioning(ctx context.Context, baseURL string, pollURL string, timeout time.Duration, identifier string, check func(map[string]any, string) (string, bool)) (string, error)
+	PollProvisioning(ctx context.Context, baseURL string, pollURL string, timeout time.Duration, identifier string, opts PollOptions, check func(map[string]any, string) (string, bool)) (string, error)
 }
 
 // BaseService holds shared transport state for service clients.
@@ -58,8 +57,8 @@ func (s BaseService) Body(res *http.Response) ([]byte, error) {
 }
 
 // PollProvisioning repeatedly polls a provisioning URL relative to the base URL.
-func (s BaseService) PollProvisioning(ctx context.Context, pollURL string, timeout time.Duration, identifier string, check func(map[string]any, string) (string, bool)) (string, error) {
-	return s.Client.PollProvisioning(ctx, s.BaseURL, pollURL, timeout, identifier, check)
+func (s BaseService) PollProvisioning(ctx context.Context, pollURL string, timeout time.Duration, identifier string, opts PollOptions, check func(map[string]any, string) (string, bool)) (string, error) {
+	return s.Client.PollProvisioning(ctx, s.BaseURL, pollURL, timeout, identifier, opts, check)
 }
 
 // GetJSON issues a GET and unmarshals the JSON response.
@@ -76,13 +75,13 @@ func (s BaseService) GetJSON(ctx context.Context, endpoint string, out any, allo
 	}
 
 	if len(allowedStatus) > 0 {
-		if err := ExpectStatus(res, body, allowedStatus...); err != nil {
+		if err := s.expectStatus(res, body, allowedStatus...); err != nil {
 			return res, body, err
 		}
 	}
 
 	if out != nil {
-		if err := json.Unmarshal(body, out); err != nil {
+		if err := s.Decode(endpoint, body, out); err != nil {
 			return res, body, err
 		}
 	}
@@ -121,13 +120,13 @@ func (s BaseService) DoJSON(ctx context.Context, method string, endpoint string,
 	}
 
 	if len(allowedStatus) > 0 {
-		if err := ExpectStatus(res, body, allowedStatus...); err != nil {
+		if err := s.expectStatus(res, body, allowedStatus...); err != nil {
 			return res, body, err
 		}
 	}
 
 	if out != nil {
-		if err := json.Unmarshal(body, out); err != nil {
+		if err := s.Decode(endpoint, body, out); err != nil {
 			return res, body, err
 		}
 	}
@@ -135,11 +134,19 @@ func (s BaseService) DoJSON(ctx context.Context, method string, endpoint string,
 	return res, body, nil
 }
 
-// ExpectStatus returns an error if the response status code is not allowed.
+// ExpectStatus returns an *APIError if the response status code is not allowed.
 func ExpectStatus(res *http.Response, body []byte, allowedStatus ...int) error {
 	if slices.Contains(allowedStatus, res.StatusCode) {
 		return nil
 	}
 
-	return fmt.Errorf("unexpected status %d: %s", res.StatusCode, string(body))
+	return NewAPIError(res, body)
+}
+
+func (s BaseService) expectStatus(res *http.Response, body []byte, allowedStatus ...int) error {
+	if slices.Contains(allowedStatus, res.StatusCode) {
+		return nil
+	}
+
+	return s.NewAPIError(res, body)
 }
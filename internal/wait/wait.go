@@ -0,0 +1,60 @@
+// Package wait polls for a condition for the wait helpers
+// of the service clients.
+package wait
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTimeout is returned by Poll and For when the timeout is reached.
+var ErrTimeout = errors.New("wait timed out")
+
+// Poll calls check every interval until it reports done, returns
+// an error, or the timeout or context ends.
+// It gives up early if the next check would be after the timeout.
+func Poll(ctx context.Context, interval, timeout time.Duration, check func(ctx context.Context) (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return ErrTimeout
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// For polls get until match reports true for its result, and returns
+// that result. On ErrTimeout it also returns the last result, so
+// callers can report the state that was reached.
+func For[T any](ctx context.Context, interval, timeout time.Duration, get func(ctx context.Context) (T, error), match func(T) bool) (T, error) {
+	var last T
+	err := Poll(ctx, interval, timeout, func(ctx context.Context) (bool, error) {
+		var err error
+		last, err = get(ctx)
+		if err != nil {
+			return false, err
+		}
+		return match(last), nil
+	})
+	if err != nil && !errors.Is(err, ErrTimeout) {
+		var zero T
+		return zero, err
+	}
+
+	return last, err
+}
@@ -0,0 +1,63 @@
+package mythicbeasts
+
+import (
+	"log/slog"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
+
+// Option configures a Client when passed to NewClient.
+type Option func(*Client)
+
+// WithDryRun enables dry-run mode.
+// Read requests are sent as normal, but mutating requests
+// (POST, PUT, PATCH and DELETE) are validated instead of being sent,
+// and the call returns an *ErrDryRun describing the request.
+// Requests to the auth service are always sent.
+//
+// If a logger is given, each intercepted request is logged to it at
+// info level with passwords, SSH keys, user data and other secret
+// fields redacted. Nothing is logged otherwise.
+func WithDryRun(logger ...*slog.Logger) Option {
+	return func(c *Client) {
+		c.dryRun = true
+		if len(logger) > 0 {
+			c.dryRunLogger = logger[0]
+		}
+	}
+}
+
+// WithErrorBodyLimit sets the number of bytes of a response body included
+// in APIError messages. The complete body is always available on the error.
+// A negative value disables truncation. Zero keeps the defaults: errors
+// from Client.Delete include 512 bytes and errors from the services,
+// including those of GetJSON and DoJSON, include the complete body.
+func WithErrorBodyLimit(n int) Option {
+	return func(c *Client) {
+		c.errorBodyLimit = n
+	}
+}
+
+// WithProtectedVPS sets the identifiers, or path.Match patterns, of
+// VPSes the VPS service refuses to delete. See vps.Service.Protected.
+func WithProtectedVPS(patterns ...string) Option {
+	return func(c *Client) {
+		c.protectedVPS = append(c.protectedVPS, patterns...)
+	}
+}
+
+// ErrMalformedResponse is returned in strict decoding mode when a
+// response does not match the type it is decoded into.
+type ErrMalformedResponse = transport.ErrMalformedResponse
+
+// WithStrictDecoding makes the services reject API responses that have
+// fields their types do not, or values of the wrong type, with an
+// ErrMalformedResponse. It is meant for detecting API drift in tests
+// and CI; by default unknown fields are ignored.
+// It also applies to the sign in response. Provisioning poll responses
+// are decoded into maps, so they are only checked for being valid JSON.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
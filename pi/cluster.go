@@ -0,0 +1,85 @@
+package pi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/batch"
+)
+
+// DefaultConcurrency is the default number of Pis
+// provisioned at once by CreateCluster.
+const DefaultConcurrency = 4
+
+// ClusterOptions controls CreateCluster.
+type ClusterOptions struct {
+	// Concurrency is the number of Pis provisioned at once.
+	// If Concurrency <= 0, DefaultConcurrency is used.
+	Concurrency int
+	// FailFast stops starting new Pis after the first failure.
+	// Pis already being provisioned are still waited for.
+	FailFast bool
+	// Create is passed to Create for every Pi.
+	Create CreateOptions
+}
+
+// ClusterNode is the outcome of provisioning one Pi of a cluster.
+type ClusterNode struct {
+	Identifier string
+	// Server is the provisioned Pi, or nil if it was not provisioned.
+	Server *Server
+	// Err is the provisioning error, if any.
+	Err error
+}
+
+// ClusterIdentifier returns the identifier of the node at index i
+// of a cluster, numbered from 1, e.g. "k3s-1".
+func ClusterIdentifier(prefix string, i int) string {
+	return fmt.Sprintf("%s-%d", prefix, i+1)
+}
+
+// CreateCluster provisions count Pis concurrently with the same request,
+// named with ClusterIdentifier, and waits for them all to be live.
+//
+// It returns a node for every Pi, in order, including those that failed,
+// and an error joining every failure, each prefixed with its identifier.
+// With FailFast set, nodes that were not started report ErrSkipped.
+// All identifiers are validated before any Pi is provisioned.
+func (s *Service) CreateCluster(ctx context.Context, prefix string, count int, req CreateRequest, opts ClusterOptions) ([]ClusterNode, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("cluster size must be positive, got %d", count)
+	}
+
+	nodes := make([]ClusterNode, count)
+	for i := range nodes {
+		nodes[i].Identifier = ClusterIdentifier(prefix, i)
+		if err := ValidateIdentifier(nodes[i].Identifier); err != nil {
+			return nil, err
+		}
+	}
+
+	batch.ForEach(ctx, count, concurrencyOrDefault(opts.Concurrency), opts.FailFast, func(ctx context.Context, i int) error {
+		nodes[i].Server, nodes[i].Err = s.Create(ctx, nodes[i].Identifier, req, opts.Create)
+		return nodes[i].Err
+	}, func(i int) {
+		nodes[i].Err = ErrSkipped
+	})
+
+	var errs []error
+	for _, node := range nodes {
+		if node.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", node.Identifier, node.Err))
+		}
+	}
+
+	return nodes, errors.Join(errs...)
+}
+
+// concurrencyOrDefault returns n, or DefaultConcurrency if n <= 0.
+func concurrencyOrDefault(n int) int {
+	if n <= 0 {
+		return DefaultConcurrency
+	}
+	return n
+}
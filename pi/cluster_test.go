@@ -0,0 +1,93 @@
+package pi_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func clusterMux(ids ...string) *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, id := range ids {
+		mux.HandleFunc("/pi/servers/"+id, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				w.Header().Set("Location", "/queue/pi/"+id)
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			_, _ = w.Write([]byte(`{"ip":"2a00:1098:8:1::1","ssh_port":5123}`))
+		})
+		mux.HandleFunc("/queue/pi/"+id, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"status":"live"}`))
+		})
+	}
+	return mux
+}
+
+func TestRaspberryPis_CreateCluster(t *testing.T) {
+	t.Parallel()
+	mux := clusterMux("k3s-1", "k3s-3")
+	mux.HandleFunc("/pi/servers/k3s-2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	nodes, err := c.Pi().CreateCluster(testContext(), "k3s", 3, piapi.CreateRequest{Model: 4}, piapi.ClusterOptions{Concurrency: 2})
+	if err == nil || !strings.Contains(err.Error(), "k3s-2: ") {
+		t.Fatalf("err=%v, want error for k3s-2", err)
+	}
+	if len(nodes) != 3 {
+		t.Fatalf("len(nodes)=%d, want 3", len(nodes))
+	}
+	for _, i := range []int{0, 2} {
+		if nodes[i].Err != nil || nodes[i].Server == nil || nodes[i].Server.Identifier != nodes[i].Identifier {
+			t.Fatalf("nodes[%d]=%+v", i, nodes[i])
+		}
+	}
+	var conflict *piapi.ErrIdentifierConflict
+	if nodes[1].Identifier != "k3s-2" || !errors.As(nodes[1].Err, &conflict) {
+		t.Fatalf("nodes[1]=%+v, want ErrIdentifierConflict", nodes[1])
+	}
+}
+
+func TestRaspberryPis_CreateCluster_InvalidPrefix(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, http.NewServeMux())
+	defer srv.Close()
+
+	var invalid *piapi.ErrInvalidIdentifier
+	if _, err := c.Pi().CreateCluster(testContext(), "K3S", 2, piapi.CreateRequest{}, piapi.ClusterOptions{}); !errors.As(err, &invalid) {
+		t.Fatalf("err=%v, want ErrInvalidIdentifier", err)
+	}
+}
+
+func TestRaspberryPis_CreateCluster_CreateOptions(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, clusterMux("k3s-1", "k3s-2"))
+	defer srv.Close()
+	c.PollInterval = time.Hour
+
+	var progress atomic.Int32
+	_, err := c.Pi().CreateCluster(testContext(), "k3s", 2, piapi.CreateRequest{Model: 4}, piapi.ClusterOptions{
+		Create: piapi.CreateOptions{
+			Timeout:      time.Second,
+			PollInterval: time.Millisecond,
+			OnProgress: func(piapi.ProvisioningStatus) {
+				progress.Add(1)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if got := progress.Load(); got != 2 {
+		t.Fatalf("progress calls=%d, want 2", got)
+	}
+}
@@ -0,0 +1,33 @@
+package pi
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// parseDiskSize parses a disk size in GB sent by the API,
+// e.g. "10" or "10.0". Sizes that cannot be parsed are 0.
+func parseDiskSize(raw string) int64 {
+	raw = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(raw), "GB"))
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(math.Round(v))
+}
+
+// MatchesDiskSize reports whether the Pi has the requested disk size in GB.
+// A requested size of 0 uses the API default and matches any size.
+func (s Server) MatchesDiskSize(requested int64) bool {
+	return requested == 0 || s.DiskSize == requested
+}
+
+// DiskSizeShortfall returns how many GB smaller the Pi's disk is
+// than requested, or 0 if it is at least as large.
+func (s Server) DiskSizeShortfall(requested int64) int64 {
+	if s.DiskSize >= requested {
+		return 0
+	}
+	return requested - s.DiskSize
+}
@@ -0,0 +1,71 @@
+package pi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// DefaultDomain is the domain Pi hostnames are assigned under.
+const DefaultDomain = "hostedpi.com"
+
+// Resolver looks up the addresses of a host.
+// *net.Resolver implements it.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// FQDN returns the fully qualified hostname of the Pi, using the
+// hostname reported by the API or else identifier.DefaultDomain.
+// Returns "" if neither is known.
+func (s Server) FQDN() string {
+	if s.Hostname != "" {
+		return s.Hostname
+	}
+	if s.Identifier == "" {
+		return ""
+	}
+	return s.Identifier + "." + DefaultDomain
+}
+
+// WaitForDNS polls DNS until the FQDN of the server resolves,
+// to the server's IP address if it has one.
+// Lookups use Service.Resolver, or net.DefaultResolver if it is nil.
+// Returns ErrWaitTimeout if the name does not resolve in time.
+func (s *Service) WaitForDNS(ctx context.Context, server Server, opts WaitOptions) error {
+	fqdn := server.FQDN()
+	if fqdn == "" {
+		return ErrEmptyIdentifier
+	}
+
+	var resolver Resolver = net.DefaultResolver
+	if s.Resolver != nil {
+		resolver = s.Resolver
+	}
+
+	want := net.ParseIP(server.IP)
+
+	last := "unresolved"
+	err := opts.poll(ctx, func(ctx context.Context) (bool, error) {
+		addrs, err := resolver.LookupIPAddr(ctx, fqdn)
+		if err != nil {
+			last = err.Error()
+			return false, nil
+		}
+		var got []string
+		for _, addr := range addrs {
+			if want == nil || addr.IP.Equal(want) {
+				return true, nil
+			}
+			got = append(got, addr.IP.String())
+		}
+		last = strings.Join(got, ", ")
+		return false, nil
+	})
+	if errors.Is(err, errWaitTimeout) {
+		return &ErrWaitTimeout{Identifier: server.Identifier, Want: "resolvable as " + fqdn, Last: last}
+	}
+
+	return err
+}
@@ -0,0 +1,85 @@
+package pi_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+type fakeResolver struct {
+	lookups int
+	after   int
+	addrs   []net.IPAddr
+}
+
+func (r *fakeResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	r.lookups++
+	if host != "web1.hostedpi.com" {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	if r.lookups < r.after {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return r.addrs, nil
+}
+
+func TestServer_FQDN(t *testing.T) {
+	t.Parallel()
+	if got := (piapi.Server{Identifier: "web1"}).FQDN(); got != "web1.hostedpi.com" {
+		t.Fatalf("fqdn=%q", got)
+	}
+	if got := (piapi.Server{Identifier: "web1", Hostname: "web1.example.com"}).FQDN(); got != "web1.example.com" {
+		t.Fatalf("fqdn=%q", got)
+	}
+}
+
+func TestRaspberryPis_WaitForDNS(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, http.NewServeMux())
+	defer srv.Close()
+
+	resolver := &fakeResolver{after: 2, addrs: []net.IPAddr{{IP: net.ParseIP("2a00:1098:8:1::1")}}}
+	c.Pi().Resolver = resolver
+
+	server := piapi.Server{Identifier: "web1", IP: "2a00:1098:8:1::1"}
+	opts := piapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second}
+	if err := c.Pi().WaitForDNS(testContext(), server, opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resolver.lookups != 2 {
+		t.Fatalf("lookups=%d, want 2", resolver.lookups)
+	}
+
+	server.IP = "2a00:1098:8:1::2"
+	opts.Timeout = 5 * time.Millisecond
+	err := c.Pi().WaitForDNS(testContext(), server, opts)
+	var timeout *piapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Last != "2a00:1098:8:1::1" {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+}
+
+func TestRaspberryPis_Create_WaitForDNSFailure(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	c, srv := newTestClient(t, provisioningMux(t, "web2", &polls))
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+	c.Pi().Resolver = &fakeResolver{}
+
+	ctx, cancel := context.WithTimeout(testContext(), 100*time.Millisecond)
+	defer cancel()
+
+	server, err := c.Pi().Create(ctx, "web2", piapi.CreateRequest{Model: 4, WaitForDNS: true})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err=%v, want context.DeadlineExceeded", err)
+	}
+	if server == nil || server.Identifier != "web2" {
+		t.Fatalf("server=%+v, want provisioned server", server)
+	}
+}
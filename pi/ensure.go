@@ -0,0 +1,99 @@
+package pi
+
+import (
+	"context"
+	"strconv"
+)
+
+// EnsureOptions controls Ensure.
+type EnsureOptions struct {
+	// Create is passed to Create when the Pi is missing.
+	Create CreateOptions
+}
+
+// Difference is a field of a Pi that does not match the spec.
+type Difference struct {
+	// Field is the API name of the field, e.g. "model".
+	Field string
+	Want  string
+	Got   string
+}
+
+// EnsureResult reports what Ensure did.
+type EnsureResult struct {
+	// Server is the Pi after any changes.
+	Server Server
+	// Created reports whether the Pi was provisioned.
+	Created bool
+	// Changed lists the API names of the fields that were updated.
+	Changed []string
+	// Unreconciled lists the differences Ensure cannot fix without
+	// replacing or reimaging the Pi.
+	Unreconciled []Difference
+}
+
+// Ensure makes the Pi match spec.
+//
+// A missing Pi is provisioned with Create. An existing Pi is compared
+// with spec: if its SSH keys differ they are replaced with the keys in
+// spec, and any other difference, such as the model, memory, CPU speed,
+// disk size or OS image, is reported in EnsureResult.Unreconciled as it
+// would require the Pi to be replaced. Empty fields in spec are not
+// compared, nor is an OS image the API does not report.
+func (s *Service) Ensure(ctx context.Context, identifier string, spec CreateRequest, opts EnsureOptions) (EnsureResult, error) {
+	server, err := s.Get(ctx, identifier)
+	if isNotFound(err) {
+		created, err := s.Create(ctx, identifier, spec, opts.Create)
+		if created == nil {
+			return EnsureResult{}, err
+		}
+		return EnsureResult{Server: *created, Created: true}, err
+	}
+	if err != nil {
+		return EnsureResult{}, err
+	}
+
+	result := EnsureResult{Server: server, Unreconciled: differences(server, spec)}
+
+	if spec.SSHKey != "" {
+		want, err := ParseSSHKeys(spec.SSHKey)
+		if err != nil {
+			return EnsureResult{}, err
+		}
+		installed, err := s.GetSSHKeys(ctx, identifier)
+		if err != nil {
+			return EnsureResult{}, err
+		}
+		if !installed.Equal(want) {
+			if _, err := s.SetSSHKeys(ctx, identifier, want, SSHKeyOptions{}); err != nil {
+				return EnsureResult{}, err
+			}
+			result.Changed = append(result.Changed, "ssh_key")
+		}
+	}
+
+	return result, nil
+}
+
+// differences lists the fields of server that do not match spec
+// and cannot be changed on an existing Pi.
+func differences(server Server, spec CreateRequest) []Difference {
+	var diffs []Difference
+	compare := func(field string, want, got int64) {
+		if want != 0 && want != got {
+			diffs = append(diffs, Difference{Field: field, Want: strconv.FormatInt(want, 10), Got: strconv.FormatInt(got, 10)})
+		}
+	}
+
+	compare("model", spec.Model, server.Model)
+	compare("memory", spec.Memory, server.Memory)
+	compare("cpu_speed", spec.CPUSpeed, server.CPUSpeed)
+	if !server.MatchesDiskSize(spec.DiskSize) {
+		compare("disk", spec.DiskSize, server.DiskSize)
+	}
+	if spec.OSImage != "" && server.OSImage != "" && spec.OSImage != server.OSImage {
+		diffs = append(diffs, Difference{Field: "os_image", Want: spec.OSImage, Got: server.OSImage})
+	}
+
+	return diffs
+}
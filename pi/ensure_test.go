@@ -0,0 +1,43 @@
+package pi_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestRaspberryPis_Ensure_ReconcilesSSHKeys(t *testing.T) {
+	t.Parallel()
+	installed := testKeyA + " old"
+	mux := sshKeyMux(t, &installed)
+	mux.HandleFunc("/pi/servers/pi1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ip":"2a00::1","ssh_port":5001,"model":3,"memory":1024,"disk_size":"10"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	spec := piapi.CreateRequest{Model: 4, DiskSize: 10, SSHKey: testKeyB}
+	result, err := c.Pi().Ensure(testContext(), "pi1", spec, piapi.EnsureOptions{})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if result.Created {
+		t.Fatal("unexpected create")
+	}
+	if fmt.Sprint(result.Changed) != "[ssh_key]" || installed != testKeyB {
+		t.Fatalf("changed=%v installed=%q", result.Changed, installed)
+	}
+	if len(result.Unreconciled) != 1 || result.Unreconciled[0] != (piapi.Difference{Field: "model", Want: "4", Got: "3"}) {
+		t.Fatalf("unreconciled=%+v, want model difference", result.Unreconciled)
+	}
+
+	result, err = c.Pi().Ensure(testContext(), "pi1", piapi.CreateRequest{Model: 3, SSHKey: testKeyB + " new comment"}, piapi.EnsureOptions{})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(result.Changed) != 0 || len(result.Unreconciled) != 0 {
+		t.Fatalf("result=%+v, want no changes", result)
+	}
+}
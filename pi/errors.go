@@ -3,12 +3,26 @@ package pi
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 )
 
 // ErrEmptyIdentifier is returned when an identifier is not used.
 // Identifiers are required for all Pi resources.
 var ErrEmptyIdentifier = errors.New("identifier is required")
 
+// ErrSkipped is reported for cluster nodes that were not started
+// because an earlier node failed.
+var ErrSkipped = errors.New("skipped after an earlier failure")
+
+// ErrCancelUnsupported is returned by ProvisioningJob.Cancel when the
+// queued job cannot be cancelled, e.g. because it has already finished.
+// The Pi is left as it is; delete it to stop it being billed.
+var ErrCancelUnsupported = errors.New("provisioning job cannot be cancelled")
+
 // ErrIdentifierConflict indicates the requested resource identifier
 // has already been used.
 type ErrIdentifierConflict struct {
@@ -18,3 +32,102 @@ type ErrIdentifierConflict struct {
 func (e *ErrIdentifierConflict) Error() string {
 	return fmt.Sprintf("identifier %q already in use", e.Identifier)
 }
+
+// ErrServerNotFound indicates no Pi has the requested IP address.
+type ErrServerNotFound struct {
+	IP string
+}
+
+func (e *ErrServerNotFound) Error() string {
+	return fmt.Sprintf("could not find pi with the ip %q", e.IP)
+}
+
+// ProvisioningError indicates provisioning a Pi failed.
+// It wraps the error returned by polling.
+type ProvisioningError struct {
+	Identifier string
+	// Phase is the last provisioning stage the Pi was seen in.
+	Phase ProvisioningState
+	// Reason is the failure reason given by the API.
+	Reason string
+	// Statuses are the poll responses received while waiting,
+	// ending with the failure.
+	Statuses []ProvisioningStatus
+	// Elapsed is the time spent waiting before the failure.
+	Elapsed time.Duration
+	Err     error
+}
+
+func (e *ProvisioningError) Error() string {
+	return fmt.Sprintf("provisioning pi %q failed while %s after %s: %s", e.Identifier, e.Phase, e.Elapsed.Round(time.Millisecond), e.Reason)
+}
+
+func (e *ProvisioningError) Unwrap() error { return e.Err }
+
+// ErrProvisioningCancelled indicates a provisioning job
+// was cancelled before the Pi was live.
+type ErrProvisioningCancelled struct {
+	Identifier string
+}
+
+func (e *ErrProvisioningCancelled) Error() string {
+	return fmt.Sprintf("provisioning of pi %q was cancelled", e.Identifier)
+}
+
+// ErrWaitTimeout indicates a Pi did not reach the wanted
+// state before the wait timed out.
+type ErrWaitTimeout struct {
+	Identifier string
+	Want       string
+	Last       string
+}
+
+func (e *ErrWaitTimeout) Error() string {
+	return fmt.Sprintf("timed out waiting for pi %q to be %q, last %q", e.Identifier, e.Want, e.Last)
+}
+
+// isNotFound reports whether err is an API 404 response.
+func isNotFound(err error) bool {
+	var apiErr *transport.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// ErrOutOfStock indicates no Pis of the requested model are
+// available to provision. It wraps the *APIError.
+type ErrOutOfStock struct {
+	// Model is the requested model, or 0 if none was given.
+	Model int64
+	// Message is the reason given by the API.
+	Message string
+	Err     error
+}
+
+func (e *ErrOutOfStock) Error() string {
+	return fmt.Sprintf("pi model %d out of stock: %s", e.Model, e.Message)
+}
+
+func (e *ErrOutOfStock) Unwrap() error { return e.Err }
+
+// ErrPaymentRequired indicates the account does not have the credit
+// or payment details to pay for the request. It wraps the *APIError.
+type ErrPaymentRequired = transport.ErrPaymentRequired
+
+// ErrQuotaExceeded indicates the request would take the account
+// over one of its limits. It wraps the *APIError.
+type ErrQuotaExceeded = transport.ErrQuotaExceeded
+
+// createError maps a failed provisioning request to ErrOutOfStock,
+// ErrPaymentRequired or ErrQuotaExceeded. A 503 is out of stock if
+// its message says no Pis are available.
+// Other errors are returned unchanged.
+func createError(err *transport.APIError, model int64) error {
+	if err.StatusCode != http.StatusServiceUnavailable {
+		return transport.AccountError(err)
+	}
+	msg := err.Message()
+	lower := strings.ToLower(msg)
+	if !strings.Contains(lower, "stock") && !strings.Contains(lower, "available") {
+		return err
+	}
+	return &ErrOutOfStock{Model: model, Message: msg, Err: err}
+}
@@ -0,0 +1,93 @@
+package pi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FlexBool is a bool that also decodes from the numbers 0 and 1 and
+// from strings such as "on", "off", "yes", "no", "true" and "false",
+// as the Pi API is not consistent about how it sends flags.
+type FlexBool bool
+
+func (b *FlexBool) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case bool:
+		*b = FlexBool(v)
+	case float64:
+		*b = v != 0
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "1", "true", "on", "yes", "y":
+			*b = true
+		case "", "0", "false", "off", "no", "n":
+			*b = false
+		default:
+			return fmt.Errorf("cannot decode %q as a bool", v)
+		}
+	default:
+		return fmt.Errorf("cannot decode %s as a bool", data)
+	}
+	return nil
+}
+
+// flexInt decodes an integer sent either as a number or a string.
+type flexInt int64
+
+func (n *flexInt) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = strings.TrimSpace(unquoted)
+		if s == "" {
+			*n = 0
+			return nil
+		}
+	}
+
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as an integer", data)
+	}
+	*n = flexInt(v)
+	return nil
+}
+
+// flexString decodes a string sent either as a string or a number.
+type flexString string
+
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var v string
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*s = flexString(v)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("cannot decode %s as a string", data)
+	}
+	*s = flexString(n.String())
+	return nil
+}
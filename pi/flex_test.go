@@ -0,0 +1,76 @@
+package pi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestServer_UnmarshalFlexibleFields(t *testing.T) {
+	t.Parallel()
+	var server piapi.Server
+	err := json.Unmarshal([]byte(`{
+		"ip": "2a00:1098:8:1::1",
+		"ssh_port": "5123",
+		"disk_size": 10,
+		"model": 4,
+		"memory": "4096",
+		"status": "live",
+		"power": "off",
+		"is_booting": 0,
+		"model_full": "Raspberry Pi 4 Model B",
+		"location": "MER",
+		"location_name": "Cambridge"
+	}`), &server)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.SSHPort != 5123 || server.DiskSize != 10 || server.DiskSizeRaw != "10" || server.Memory != 4096 || server.Model != 4 {
+		t.Fatalf("server=%+v", server)
+	}
+	if server.Status != "live" || server.Power || server.IsBooting || server.ModelFull != "Raspberry Pi 4 Model B" || server.LocationName != "Cambridge" {
+		t.Fatalf("server=%+v", server)
+	}
+}
+
+func TestFlexBool(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]bool{
+		`true`: true, `false`: false, `1`: true, `0`: false,
+		`"on"`: true, `"off"`: false, `"yes"`: true, `""`: false,
+	} {
+		var b piapi.FlexBool
+		if err := json.Unmarshal([]byte(in), &b); err != nil {
+			t.Fatalf("%s: err: %v", in, err)
+		}
+		if bool(b) != want {
+			t.Fatalf("%s: got %v, want %v", in, b, want)
+		}
+	}
+
+	var b piapi.FlexBool
+	if err := json.Unmarshal([]byte(`"maybe"`), &b); err == nil {
+		t.Fatalf("expected error for \"maybe\"")
+	}
+}
+
+func TestServer_DiskSize(t *testing.T) {
+	t.Parallel()
+	var server piapi.Server
+	if err := json.Unmarshal([]byte(`{"disk_size":"20.0"}`), &server); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.DiskSize != 20 || server.DiskSizeRaw != "20.0" {
+		t.Fatalf("server=%+v", server)
+	}
+	if !server.MatchesDiskSize(20) || !server.MatchesDiskSize(0) || server.MatchesDiskSize(30) {
+		t.Fatalf("MatchesDiskSize gave unexpected results for %d", server.DiskSize)
+	}
+	if got := server.DiskSizeShortfall(30); got != 10 {
+		t.Fatalf("DiskSizeShortfall(30)=%d, want 10", got)
+	}
+	if got := server.DiskSizeShortfall(10); got != 0 {
+		t.Fatalf("DiskSizeShortfall(10)=%d, want 0", got)
+	}
+}
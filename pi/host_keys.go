@@ -0,0 +1,51 @@
+package pi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrHostKeysUnavailable is returned by ScanHostKeys when the Pi
+// offers none of the host key types it asks for.
+var ErrHostKeysUnavailable = errors.New("host keys are not available")
+
+// HostKey is an SSH host key of a Pi.
+type HostKey struct {
+	// Type is the key type, e.g. "ssh-ed25519".
+	Type string
+	// Key is the base64 key data.
+	Key string
+	// Fingerprint is the SHA256 fingerprint, as shown by ssh-keygen -l.
+	Fingerprint string
+}
+
+// String returns the key as "<type> <base64>".
+func (k HostKey) String() string {
+	return k.Type + " " + k.Key
+}
+
+// KnownHosts returns known_hosts lines for the Pi with the given keys,
+// naming the Pi by every address it can be reached at: its SSH proxy
+// host on its SSH port, and its FQDN and IP address on port 22.
+// Returns ErrSSHUnavailable if there is no hostname or IP address.
+func (s Server) KnownHosts(keys []HostKey) (string, error) {
+	var names []string
+	if host, port, err := s.sshHost(SSHRouteProxy); err == nil {
+		names = append(names, knownHostsAddr(host, port))
+	}
+	for _, host := range []string{s.FQDN(), s.IP} {
+		if host != "" {
+			names = append(names, knownHostsAddr(host, DefaultSSHPort))
+		}
+	}
+	if len(names) == 0 {
+		return "", ErrSSHUnavailable
+	}
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s %s\n", strings.Join(names, ","), key)
+	}
+	return b.String(), nil
+}
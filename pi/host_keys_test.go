@@ -0,0 +1,167 @@
+package pi_test
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestServer_KnownHosts(t *testing.T) {
+	t.Parallel()
+	keys := []piapi.HostKey{{Type: "ssh-ed25519", Key: "AAAAC3Nza"}, {Type: "ssh-rsa", Key: "AAAAB3Nza"}}
+
+	server := piapi.Server{Identifier: "pi1", IP: "2a00:1098:8:1::1", SSHPort: 5001}
+	got, err := server.KnownHosts(keys)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := "[ssh.pi1.hostedpi.com]:5001,pi1.hostedpi.com,2a00:1098:8:1::1 ssh-ed25519 AAAAC3Nza\n" +
+		"[ssh.pi1.hostedpi.com]:5001,pi1.hostedpi.com,2a00:1098:8:1::1 ssh-rsa AAAAB3Nza\n"
+	if got != want {
+		t.Fatalf("known hosts=%q, want %q", got, want)
+	}
+}
+
+func TestScanHostKeys(t *testing.T) {
+	t.Parallel()
+	blob := append(sshTestString([]byte("ssh-ed25519")), sshTestString(make([]byte, 32))...)
+	addr, stop := fakeSSHServer(t, "ssh-ed25519", blob)
+	defer stop()
+
+	var dialed []string
+	server := piapi.Server{Identifier: "pi1", IP: "2a00:1098:8:1::1", SSHPort: 5001}
+	opts := piapi.SSHWaitOptions{
+		Dial: dialTo(addr, &dialed),
+		Wait: piapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+	}
+	keys, err := piapi.ScanHostKeys(testContext(), server, opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	sum := sha256.Sum256(blob)
+	want := piapi.HostKey{
+		Type:        "ssh-ed25519",
+		Key:         base64.StdEncoding.EncodeToString(blob),
+		Fingerprint: "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]),
+	}
+	if len(keys) != 1 || keys[0] != want {
+		t.Fatalf("keys=%+v, want [%+v]", keys, want)
+	}
+	if dialed[0] != "ssh.pi1.hostedpi.com:5001" {
+		t.Fatalf("dialed=%v, want ssh.pi1.hostedpi.com:5001", dialed)
+	}
+}
+
+func TestScanHostKeys_NoneOffered(t *testing.T) {
+	t.Parallel()
+	addr, stop := fakeSSHServer(t, "ssh-dss", nil)
+	defer stop()
+
+	var dialed []string
+	server := piapi.Server{Identifier: "pi1", SSHPort: 5001}
+	opts := piapi.SSHWaitOptions{
+		Dial: dialTo(addr, &dialed),
+		Wait: piapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+	}
+	if _, err := piapi.ScanHostKeys(testContext(), server, opts); !errors.Is(err, piapi.ErrHostKeysUnavailable) {
+		t.Fatalf("err=%v, want ErrHostKeysUnavailable", err)
+	}
+}
+
+// fakeSSHServer runs the server side of a key exchange, replying with
+// blob to clients that offer algorithm and disconnecting the others.
+func fakeSSHServer(t *testing.T, algorithm string, blob []byte) (*net.TCPAddr, func()) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSSH(conn, algorithm, blob)
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr), func() { _ = ln.Close() }
+}
+
+func serveFakeSSH(conn net.Conn, algorithm string, blob []byte) {
+	defer conn.Close()
+	_, _ = conn.Write([]byte("SSH-2.0-fake\r\n"))
+
+	r := bufio.NewReader(conn)
+	if _, err := r.ReadString('\n'); err != nil {
+		return
+	}
+	kexInit, err := readTestPacket(r)
+	if err != nil || len(kexInit) < 17 {
+		return
+	}
+	_, rest := parseTestString(kexInit[17:])
+	offered, _ := parseTestString(rest)
+
+	writeTestPacket(conn, []byte{4, 0})
+	writeTestPacket(conn, []byte{20})
+	if _, err := readTestPacket(r); err != nil {
+		return
+	}
+
+	if string(offered) != algorithm {
+		writeTestPacket(conn, append([]byte{1, 0, 0, 0, 3}, sshTestString([]byte("no matching host key type"))...))
+		return
+	}
+	reply := append([]byte{31}, sshTestString(blob)...)
+	reply = append(reply, sshTestString(make([]byte, 32))...)
+	reply = append(reply, sshTestString([]byte("signature"))...)
+	writeTestPacket(conn, reply)
+}
+
+func readTestPacket(r io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	body := make([]byte, binary.BigEndian.Uint32(header[:4])-1)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body[:len(body)-int(header[4])], nil
+}
+
+func writeTestPacket(w io.Writer, payload []byte) {
+	padding := 8 - (5+len(payload))%8
+	if padding < 4 {
+		padding += 8
+	}
+	packet := binary.BigEndian.AppendUint32(nil, uint32(1+len(payload)+padding))
+	packet = append(packet, byte(padding))
+	packet = append(packet, payload...)
+	_, _ = w.Write(append(packet, make([]byte, padding)...))
+}
+
+func sshTestString(b []byte) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(b))), b...)
+}
+
+func parseTestString(b []byte) ([]byte, []byte) {
+	if len(b) < 4 {
+		return nil, nil
+	}
+	n := int(binary.BigEndian.Uint32(b))
+	if n > len(b)-4 {
+		return nil, nil
+	}
+	return b[4 : 4+n], b[4+n:]
+}
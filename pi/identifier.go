@@ -0,0 +1,48 @@
+package pi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxIdentifierLength is the longest identifier a Pi can have,
+// as it is used as a DNS label in the Pi's hostname.
+const MaxIdentifierLength = 63
+
+// ErrInvalidIdentifier indicates an identifier the API would reject.
+type ErrInvalidIdentifier struct {
+	Identifier string
+	Reason     string
+}
+
+func (e *ErrInvalidIdentifier) Error() string {
+	return fmt.Sprintf("invalid identifier %q: %s", e.Identifier, e.Reason)
+}
+
+// ValidateIdentifier checks that identifier can be used for a Pi.
+// Identifiers become part of the Pi's hostname, so they must be
+// 1 to 63 lower case letters, digits and hyphens, and must not
+// start or end with a hyphen.
+// Returns ErrEmptyIdentifier if the identifier is blank and
+// ErrInvalidIdentifier if it breaks any other rule.
+func ValidateIdentifier(identifier string) error {
+	if strings.TrimSpace(identifier) == "" {
+		return ErrEmptyIdentifier
+	}
+	if len(identifier) > MaxIdentifierLength {
+		return &ErrInvalidIdentifier{Identifier: identifier, Reason: fmt.Sprintf("longer than %d characters", MaxIdentifierLength)}
+	}
+	for _, r := range identifier {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+		case r >= 'A' && r <= 'Z':
+			return &ErrInvalidIdentifier{Identifier: identifier, Reason: "must be lower case"}
+		default:
+			return &ErrInvalidIdentifier{Identifier: identifier, Reason: fmt.Sprintf("contains %q; only letters, digits and hyphens are allowed", r)}
+		}
+	}
+	if strings.HasPrefix(identifier, "-") || strings.HasSuffix(identifier, "-") {
+		return &ErrInvalidIdentifier{Identifier: identifier, Reason: "must not start or end with a hyphen"}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package pi_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestValidateIdentifier(t *testing.T) {
+	t.Parallel()
+	for _, id := range []string{"pi1", "web-01", "a", strings.Repeat("a", 63)} {
+		if err := piapi.ValidateIdentifier(id); err != nil {
+			t.Fatalf("%q: err: %v", id, err)
+		}
+	}
+
+	if err := piapi.ValidateIdentifier(" "); !errors.Is(err, piapi.ErrEmptyIdentifier) {
+		t.Fatalf("err=%v, want ErrEmptyIdentifier", err)
+	}
+	for _, id := range []string{"Web1", "web_1", "web.1", "-web", "web-", strings.Repeat("a", 64)} {
+		var invalid *piapi.ErrInvalidIdentifier
+		if err := piapi.ValidateIdentifier(id); !errors.As(err, &invalid) {
+			t.Fatalf("%q: err=%v, want ErrInvalidIdentifier", id, err)
+		}
+	}
+}
+
+func TestRaspberryPis_InvalidIdentifierSkipsRequest(t *testing.T) {
+	t.Parallel()
+	requests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	var invalid *piapi.ErrInvalidIdentifier
+	if _, err := c.Pi().Create(testContext(), "My_Pi", piapi.CreateRequest{}); !errors.As(err, &invalid) {
+		t.Fatalf("create err=%v, want ErrInvalidIdentifier", err)
+	}
+	if _, err := c.Pi().Get(testContext(), "My_Pi"); !errors.As(err, &invalid) {
+		t.Fatalf("get err=%v, want ErrInvalidIdentifier", err)
+	}
+	if err := c.Pi().Delete(testContext(), "My_Pi"); !errors.As(err, &invalid) {
+		t.Fatalf("delete err=%v, want ErrInvalidIdentifier", err)
+	}
+	if requests != 0 {
+		t.Fatalf("requests=%d, want 0", requests)
+	}
+}
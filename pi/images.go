@@ -0,0 +1,90 @@
+package pi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
+
+// OperatingSystem represents an operating system image
+// that can be installed on a Pi.
+type OperatingSystem struct {
+	// ID is the image identifier used in CreateRequest.OSImage.
+	ID string `json:"id"`
+	// Name is the display name.
+	Name string `json:"name"`
+	// Architecture is the CPU architecture, e.g. "arm64" or "armhf".
+	// If the API does not report it, it is inferred from the ID.
+	Architecture string `json:"architecture,omitempty"`
+	// Is64Bit reports whether the image is 64-bit.
+	Is64Bit bool `json:"is_64bit,omitempty"`
+	// DefaultUser is the login user of the image, if the API reports it.
+	DefaultUser string `json:"default_user,omitempty"`
+}
+
+// UnmarshalJSON decodes an image from either its display name,
+// as the API has always sent, or an object with its details.
+func (o *OperatingSystem) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*o = OperatingSystem{Name: name}
+		return nil
+	}
+
+	type plain OperatingSystem
+	aux := struct {
+		*plain
+		Description string `json:"description"`
+	}{plain: (*plain)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if o.Name == "" {
+		o.Name = aux.Description
+	}
+	return nil
+}
+
+// inferArchitecture fills in the architecture and 64-bit flag
+// from the image ID if the API did not report them.
+func (o *OperatingSystem) inferArchitecture() {
+	if o.Architecture == "" {
+		id := strings.ToLower(o.ID)
+		switch {
+		case strings.Contains(id, "arm64"), strings.Contains(id, "aarch64"):
+			o.Architecture = "arm64"
+		case strings.Contains(id, "armhf"), strings.Contains(id, "armv7"):
+			o.Architecture = "armhf"
+		}
+	}
+	if o.Architecture == "arm64" || o.Architecture == "aarch64" {
+		o.Is64Bit = true
+	}
+}
+
+// ListOperatingSystems retrieves the operating system images
+// available for the specified Pi model, sorted by ID.
+func (s *Service) ListOperatingSystems(ctx context.Context, model int64) ([]OperatingSystem, error) {
+	url := fmt.Sprintf("/pi/images/%d", model)
+
+	var result map[string]OperatingSystem
+	if _, _, err := s.GetJSON(transport.Cacheable(ctx), url, &result); err != nil {
+		return nil, err
+	}
+
+	images := make([]OperatingSystem, 0, len(result))
+	for id, image := range result {
+		if image.ID == "" {
+			image.ID = id
+		}
+		image.inferArchitecture()
+		images = append(images, image)
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].ID < images[j].ID })
+
+	return images, nil
+}
@@ -0,0 +1,45 @@
+package pi_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRaspberryPis_ListOperatingSystems(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/images/4", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"rpi-bookworm-armhf": "Raspberry Pi OS Bookworm (32-bit)",
+			"rpi-bookworm-arm64": "Raspberry Pi OS Bookworm (64-bit)",
+			"ubuntu-noble": {"name": "Ubuntu 24.04", "architecture": "arm64", "default_user": "ubuntu"}
+		}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	images, err := c.Pi().ListOperatingSystems(testContext(), 4)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(images) != 3 {
+		t.Fatalf("images=%+v", images)
+	}
+	if images[0].ID != "rpi-bookworm-arm64" || !images[0].Is64Bit || images[0].Architecture != "arm64" {
+		t.Fatalf("images[0]=%+v", images[0])
+	}
+	if images[1].ID != "rpi-bookworm-armhf" || images[1].Is64Bit || images[1].Architecture != "armhf" {
+		t.Fatalf("images[1]=%+v", images[1])
+	}
+	if images[2].Name != "Ubuntu 24.04" || !images[2].Is64Bit || images[2].DefaultUser != "ubuntu" {
+		t.Fatalf("images[2]=%+v", images[2])
+	}
+
+	names, err := c.Pi().GetOperatingSystems(testContext(), 4)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if names["ubuntu-noble"] != "Ubuntu 24.04" || names["rpi-bookworm-arm64"] != "Raspberry Pi OS Bookworm (64-bit)" {
+		t.Fatalf("names=%v", names)
+	}
+}
@@ -0,0 +1,115 @@
+package pi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultInventoryGroup is the Ansible group Pis are exported to.
+const DefaultInventoryGroup = "pis"
+
+// InventoryOptions controls the inventory exports.
+type InventoryOptions struct {
+	// Group is the Ansible group of the hosts.
+	// If empty, DefaultInventoryGroup is used.
+	Group string
+	// User is the SSH user. If empty, DefaultSSHUser is used.
+	User string
+	// Route selects how the Pis are reached.
+	Route SSHRoute
+}
+
+// inventoryHost is a Pi as it appears in an inventory.
+type inventoryHost struct {
+	alias string
+	host  string
+	port  int64
+}
+
+// inventoryHosts returns the hosts of servers sorted by alias.
+// Each host is named by its identifier, or its address if it has none.
+func inventoryHosts(servers []Server, route SSHRoute) ([]inventoryHost, error) {
+	hosts := make([]inventoryHost, 0, len(servers))
+	for _, server := range servers {
+		host, port, err := server.sshHost(route)
+		if err != nil {
+			return nil, fmt.Errorf("pi %q: %w", server.Identifier, err)
+		}
+		alias := server.Identifier
+		if alias == "" {
+			alias = host
+		}
+		hosts = append(hosts, inventoryHost{alias: alias, host: host, port: port})
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].alias < hosts[j].alias })
+	return hosts, nil
+}
+
+func (o InventoryOptions) group() string {
+	if o.Group == "" {
+		return DefaultInventoryGroup
+	}
+	return o.Group
+}
+
+func (o InventoryOptions) user() string {
+	if o.User == "" {
+		return DefaultSSHUser
+	}
+	return o.User
+}
+
+// AnsibleINI returns an Ansible inventory in INI format with a host
+// for every Pi, connecting to it over opts.Route.
+// Returns ErrSSHUnavailable if a Pi cannot be reached over opts.Route.
+func AnsibleINI(servers []Server, opts InventoryOptions) (string, error) {
+	hosts, err := inventoryHosts(servers, opts.Route)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s]\n", opts.group())
+	for _, h := range hosts {
+		fmt.Fprintf(&b, "%s ansible_host=%s ansible_port=%d ansible_user=%s\n", h.alias, h.host, h.port, opts.user())
+	}
+	return b.String(), nil
+}
+
+// AnsibleYAML returns an Ansible inventory in YAML format with a host
+// for every Pi, connecting to it over opts.Route.
+// Returns ErrSSHUnavailable if a Pi cannot be reached over opts.Route.
+func AnsibleYAML(servers []Server, opts InventoryOptions) (string, error) {
+	hosts, err := inventoryHosts(servers, opts.Route)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("all:\n  children:\n")
+	fmt.Fprintf(&b, "    %s:\n      hosts:\n", opts.group())
+	for _, h := range hosts {
+		fmt.Fprintf(&b, "        %s:\n", h.alias)
+		fmt.Fprintf(&b, "          ansible_host: %q\n", h.host)
+		fmt.Fprintf(&b, "          ansible_port: %d\n", h.port)
+		fmt.Fprintf(&b, "          ansible_user: %q\n", opts.user())
+	}
+	return b.String(), nil
+}
+
+// SSHConfigFile returns an ssh_config file with a Host entry for
+// every Pi, named by its identifier. See Server.SSHConfig.
+// Returns ErrSSHUnavailable if a Pi cannot be reached over opts.Route.
+func SSHConfigFile(servers []Server, opts InventoryOptions) (string, error) {
+	hosts, err := inventoryHosts(servers, opts.Route)
+	if err != nil {
+		return "", err
+	}
+
+	entries := make([]string, len(hosts))
+	for i, h := range hosts {
+		entries[i] = fmt.Sprintf("Host %s\n    HostName %s\n    Port %d\n    User %s\n", h.alias, h.host, h.port, opts.user())
+	}
+	return strings.Join(entries, "\n"), nil
+}
@@ -0,0 +1,75 @@
+package pi_test
+
+import (
+	"errors"
+	"testing"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+var inventoryServers = []piapi.Server{
+	{Identifier: "web2", IP: "2a00:1098:8:1::2", SSHPort: 5002},
+	{Identifier: "web1", IP: "2a00:1098:8:1::1", SSHPort: 5001},
+	{IP: "2a00:1098:8:1::3", SSHPort: 5003},
+}
+
+func TestAnsibleINI(t *testing.T) {
+	t.Parallel()
+	got, err := piapi.AnsibleINI(inventoryServers, piapi.InventoryOptions{Group: "k3s"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := "[k3s]\n" +
+		"2a00:1098:8:1::3 ansible_host=2a00:1098:8:1::3 ansible_port=22 ansible_user=root\n" +
+		"web1 ansible_host=ssh.web1.hostedpi.com ansible_port=5001 ansible_user=root\n" +
+		"web2 ansible_host=ssh.web2.hostedpi.com ansible_port=5002 ansible_user=root\n"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestAnsibleYAML(t *testing.T) {
+	t.Parallel()
+	got, err := piapi.AnsibleYAML(inventoryServers[:1], piapi.InventoryOptions{User: "pi"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := `all:
+  children:
+    pis:
+      hosts:
+        web2:
+          ansible_host: "ssh.web2.hostedpi.com"
+          ansible_port: 5002
+          ansible_user: "pi"
+`
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSSHConfigFile(t *testing.T) {
+	t.Parallel()
+	got, err := piapi.SSHConfigFile(inventoryServers[:2], piapi.InventoryOptions{})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := "Host web1\n    HostName ssh.web1.hostedpi.com\n    Port 5001\n    User root\n" +
+		"\n" +
+		"Host web2\n    HostName ssh.web2.hostedpi.com\n    Port 5002\n    User root\n"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	got, err = piapi.SSHConfigFile(inventoryServers[1:2], piapi.InventoryOptions{Route: piapi.SSHRouteDirect})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if want := "Host web1\n    HostName web1.hostedpi.com\n    Port 22\n    User root\n"; got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if _, err := piapi.SSHConfigFile([]piapi.Server{{}}, piapi.InventoryOptions{}); !errors.Is(err, piapi.ErrSSHUnavailable) {
+		t.Fatalf("err=%v, want ErrSSHUnavailable", err)
+	}
+}
@@ -0,0 +1,265 @@
+package pi
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sshScanAlgorithms are the host key algorithms ScanHostKeys asks
+// for, one connection each.
+var sshScanAlgorithms = []string{"ssh-ed25519", "ecdsa-sha2-nistp256", "rsa-sha2-512"}
+
+// sshClientVersion is the identification line sent by ScanHostKeys.
+const sshClientVersion = "SSH-2.0-mythicbeasts-client-go"
+
+// sshMaxPacket bounds the size of a packet read during a scan.
+const sshMaxPacket = 256 * 1024
+
+// SSH message numbers used during a scan, from RFC 4253 and RFC 5656.
+const (
+	sshMsgDisconnect    = 1
+	sshMsgIgnore        = 2
+	sshMsgUnimplemented = 3
+	sshMsgDebug         = 4
+	sshMsgKexInit       = 20
+	sshMsgKexECDHInit   = 30
+	sshMsgKexECDHReply  = 31
+)
+
+// ScanHostKeys waits for SSH on the Pi over opts.Route and then reads
+// its host keys from the key exchange, so known_hosts can be populated
+// before the first connection. The API does not report host keys.
+//
+// The key exchange is stopped once the server has sent its host key,
+// so the server's signature over the exchange is never checked. The
+// returned keys are what the peer claimed, not proven to belong to it:
+// anyone able to intercept the connection can substitute their own.
+// Only trust them as far as the network path to the Pi, or compare
+// their fingerprints with ones obtained out of band.
+// Returns ErrHostKeysUnavailable if the Pi offers none of the key
+// types asked for.
+func ScanHostKeys(ctx context.Context, server Server, opts SSHWaitOptions) ([]HostKey, error) {
+	opts.Banner = true
+	if err := WaitForSSH(ctx, server, opts); err != nil {
+		return nil, err
+	}
+
+	host, port, err := server.sshHost(opts.Route)
+	if err != nil {
+		return nil, err
+	}
+	addr := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+	dial := opts.dialer()
+
+	var keys []HostKey
+	var errs []error
+	for _, algorithm := range sshScanAlgorithms {
+		key, err := scanHostKey(ctx, dial, addr, algorithm)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", algorithm, err))
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: %w", ErrHostKeysUnavailable, errors.Join(errs...))
+	}
+
+	return keys, nil
+}
+
+// scanHostKey runs a curve25519 key exchange with the SSH server at
+// addr offering only algorithm, and returns the host key it replies with.
+func scanHostKey(ctx context.Context, dial func(ctx context.Context, network, addr string) (net.Conn, error), addr, algorithm string) (HostKey, error) {
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return HostKey{}, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(sshBannerTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return HostKey{}, err
+	}
+
+	if _, err := io.WriteString(conn, sshClientVersion+"\r\n"); err != nil {
+		return HostKey{}, err
+	}
+	r := bufio.NewReader(conn)
+	if err := readSSHBanner(r); err != nil {
+		return HostKey{}, err
+	}
+
+	if err := writeSSHPacket(conn, sshKexInit(algorithm)); err != nil {
+		return HostKey{}, err
+	}
+	if _, err := readSSHMessage(r, sshMsgKexInit); err != nil {
+		return HostKey{}, err
+	}
+
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return HostKey{}, err
+	}
+	init := append([]byte{sshMsgKexECDHInit}, sshString(priv.PublicKey().Bytes())...)
+	if err := writeSSHPacket(conn, init); err != nil {
+		return HostKey{}, err
+	}
+
+	reply, err := readSSHMessage(r, sshMsgKexECDHReply)
+	if err != nil {
+		return HostKey{}, err
+	}
+	blob, _, ok := parseSSHString(reply[1:])
+	if !ok {
+		return HostKey{}, errors.New("malformed key exchange reply")
+	}
+	keyType, _, ok := parseSSHString(blob)
+	if !ok {
+		return HostKey{}, errors.New("malformed host key")
+	}
+
+	sum := sha256.Sum256(blob)
+	return HostKey{
+		Type:        string(keyType),
+		Key:         base64.StdEncoding.EncodeToString(blob),
+		Fingerprint: "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]),
+	}, nil
+}
+
+// readSSHBanner reads the SSH identification line from r.
+func readSSHBanner(r *bufio.Reader) error {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("reading ssh banner: %w", err)
+	}
+	if !strings.HasPrefix(line, "SSH-") {
+		return fmt.Errorf("unexpected ssh banner %q", strings.TrimSpace(line))
+	}
+
+	return nil
+}
+
+// sshKexInit returns a KEXINIT payload offering curve25519 key
+// exchange and only the given host key algorithm.
+func sshKexInit(algorithm string) []byte {
+	payload := []byte{sshMsgKexInit}
+	cookie := make([]byte, 16)
+	_, _ = rand.Read(cookie)
+	payload = append(payload, cookie...)
+
+	ciphers := "chacha20-poly1305@openssh.com,aes128-ctr,aes256-ctr,aes128-gcm@openssh.com,aes256-gcm@openssh.com"
+	macs := "hmac-sha2-256-etm@openssh.com,hmac-sha2-256,hmac-sha2-512"
+	for _, list := range []string{
+		"curve25519-sha256,curve25519-sha256@libssh.org",
+		algorithm,
+		ciphers, ciphers,
+		macs, macs,
+		"none", "none",
+		"", "",
+	} {
+		payload = append(payload, sshString([]byte(list))...)
+	}
+
+	// first_kex_packet_follows and the reserved uint32.
+	return append(payload, 0, 0, 0, 0, 0)
+}
+
+// readSSHMessage reads packets from r until one that is not ignorable,
+// and returns its payload if it is of type want.
+func readSSHMessage(r *bufio.Reader, want byte) ([]byte, error) {
+	for {
+		payload, err := readSSHPacket(r)
+		if err != nil {
+			return nil, err
+		}
+
+		switch payload[0] {
+		case want:
+			return payload, nil
+		case sshMsgIgnore, sshMsgDebug, sshMsgUnimplemented:
+			continue
+		case sshMsgDisconnect:
+			reason := ""
+			if len(payload) >= 5 {
+				if msg, _, ok := parseSSHString(payload[5:]); ok {
+					reason = string(msg)
+				}
+			}
+			return nil, fmt.Errorf("ssh server disconnected: %s", reason)
+		default:
+			return nil, fmt.Errorf("unexpected ssh message %d, want %d", payload[0], want)
+		}
+	}
+}
+
+// readSSHPacket reads an unencrypted binary packet from r
+// and returns its payload.
+func readSSHPacket(r *bufio.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	padding := uint32(header[4])
+	if length > sshMaxPacket || padding+1 >= length {
+		return nil, fmt.Errorf("invalid ssh packet length %d", length)
+	}
+
+	body := make([]byte, length-1)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+
+	return body[:len(body)-int(padding)], nil
+}
+
+// writeSSHPacket writes payload to w as an unencrypted binary packet.
+func writeSSHPacket(w io.Writer, payload []byte) error {
+	padding := 8 - (5+len(payload))%8
+	if padding < 4 {
+		padding += 8
+	}
+
+	packet := binary.BigEndian.AppendUint32(nil, uint32(1+len(payload)+padding))
+	packet = append(packet, byte(padding))
+	packet = append(packet, payload...)
+	packet = append(packet, make([]byte, padding)...)
+
+	_, err := w.Write(packet)
+	return err
+}
+
+// sshString encodes b as an SSH string.
+func sshString(b []byte) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(b))), b...)
+}
+
+// parseSSHString decodes an SSH string from the start of b
+// and returns it with the rest of b.
+func parseSSHString(b []byte) ([]byte, []byte, bool) {
+	if len(b) < 4 {
+		return nil, nil, false
+	}
+	n := binary.BigEndian.Uint32(b)
+	if uint64(n) > uint64(len(b)-4) {
+		return nil, nil, false
+	}
+
+	return b[4 : 4+n], b[4+n:], true
+}
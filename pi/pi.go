@@ -6,9 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"sort"
 	"strings"
-	"time"
 
 	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 )
@@ -16,9 +17,21 @@ import (
 // BaseURL is the default base URL for Raspberry Pi API requests.
 const BaseURL string = "https://api.mythic-beasts.com/beta"
 
+// RetryPolicy controls retries of provisioning requests.
+type RetryPolicy = transport.RetryPolicy
+
 // Service provides access to the Raspberry Pi API.
 type Service struct {
 	transport.BaseService
+
+	// CreateRetry controls retries of the provisioning request made by Create.
+	// Before each retry the identifier is checked so a Pi is never
+	// provisioned twice. By default a single attempt is made.
+	CreateRetry RetryPolicy
+
+	// Resolver is used by WaitForDNS.
+	// If nil, net.DefaultResolver is used.
+	Resolver Resolver
 }
 
 // NewService constructs a Raspberry Pi API service client.
@@ -33,12 +46,35 @@ type Model struct {
 	Memory   int64 `json:"memory"`
 	NICSpeed int64 `json:"nic_speed"`
 	CPUSpeed int64 `json:"cpu_speed"`
+
+	// Price is the monthly price in pence, if the API reports it.
+	Price int64 `json:"price,omitempty"`
+	// Available reports whether the model can currently be provisioned.
+	// It is nil if the API does not report availability.
+	Available *bool `json:"available,omitempty"`
+	// Stock is the number of Pis of this model that can be provisioned.
+	// It is nil if the API does not report stock.
+	Stock *int64 `json:"stock,omitempty"`
+}
+
+// InStock reports whether the model can currently be provisioned.
+// Models are assumed to be in stock unless the API reports
+// them as unavailable or with no stock.
+func (m Model) InStock() bool {
+	if m.Available != nil && !*m.Available {
+		return false
+	}
+	if m.Stock != nil && *m.Stock <= 0 {
+		return false
+	}
+	return true
 }
 
 // ListModels retrieves the list of available Pi models
-// that can be provisioned by Mythic Beasts.
+// that can be provisioned by Mythic Beasts, with their price
+// and stock where the API reports them. See Model.InStock.
 func (s *Service) ListModels(ctx context.Context) ([]Model, error) {
-	res, err := s.BaseService.Get(ctx, "/pi/models")
+	res, err := s.BaseService.Get(transport.Cacheable(ctx), "/pi/models")
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +92,7 @@ func (s *Service) ListModels(ctx context.Context) ([]Model, error) {
 		Models []Model `json:"models"`
 	}
 
-	if err = json.Unmarshal(body, &result); err != nil {
+	if err = s.Decode("/pi/models", body, &result); err != nil {
 		return nil, err
 	}
 
@@ -68,29 +104,89 @@ type OperatingSystems map[string]string
 
 // GetOperatingSystems retrieves the available operating
 // system images for the specified Pi model.
+// Use ListOperatingSystems for the image details.
 func (s *Service) GetOperatingSystems(ctx context.Context, model int64) (OperatingSystems, error) {
-	url := fmt.Sprintf("/pi/images/%d", model)
-
-	var result OperatingSystems
-	_, _, err := s.GetJSON(ctx, url, &result)
+	images, err := s.ListOperatingSystems(ctx, model)
 	if err != nil {
 		return nil, err
 	}
 
+	result := make(OperatingSystems, len(images))
+	for _, image := range images {
+		result[image.ID] = image.Name
+	}
+
 	return result, nil
 }
 
 // Server represents a provisioned Pi server and its attributes.
 type Server struct {
-	IP              string `json:"ip"`
-	SSHPort         int64  `json:"ssh_port"`
-	DiskSize        string `json:"disk_size"`
+	// Identifier is the identifier the Pi was provisioned with.
+	Identifier string `json:"identifier,omitempty"`
+	IP         string `json:"ip"`
+	SSHPort    int64  `json:"ssh_port"`
+	// DiskSize is the disk size in GB.
+	DiskSize int64 `json:"disk_size"`
+	// DiskSizeRaw is disk_size as sent by the API, which may be a string.
+	DiskSizeRaw     string `json:"-"`
 	InitializedKeys bool   `json:"initialized_keys"`
 	Location        string `json:"location"`
 	Model           int64  `json:"model"`
 	Memory          int64  `json:"memory"`
 	CPUSpeed        int64  `json:"cpu_speed"`
 	NICSpeed        int64  `json:"nic_speed"`
+
+	// Status is the provisioning status, e.g. "live".
+	Status string `json:"status,omitempty"`
+	// Power reports whether the Pi is powered on. See PowerState.
+	Power FlexBool `json:"power"`
+	// IsBooting reports whether the Pi is booting.
+	IsBooting FlexBool `json:"is_booting"`
+	// ModelFull is the full model name, e.g. "Raspberry Pi 4 Model B".
+	ModelFull string `json:"model_full,omitempty"`
+	// LocationName is the display name of Location.
+	LocationName string `json:"location_name,omitempty"`
+	// Hostname is the DNS name assigned to the Pi. See FQDN.
+	Hostname string `json:"hostname,omitempty"`
+	// OSImage is the installed operating system image,
+	// if the API reports it.
+	OSImage string `json:"os_image,omitempty"`
+
+	// powerReported records whether the API sent Power.
+	powerReported bool
+}
+
+// UnmarshalJSON decodes the server, accepting numeric fields sent
+// as strings and the disk size sent as a number.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	aux := struct {
+		*plain
+		SSHPort  flexInt    `json:"ssh_port"`
+		DiskSize flexString `json:"disk_size"`
+		Model    flexInt    `json:"model"`
+		Memory   flexInt    `json:"memory"`
+		CPUSpeed flexInt    `json:"cpu_speed"`
+		NICSpeed flexInt    `json:"nic_speed"`
+		Power    *FlexBool  `json:"power"`
+	}{plain: (*plain)(s)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	s.SSHPort = int64(aux.SSHPort)
+	s.DiskSizeRaw = string(aux.DiskSize)
+	s.DiskSize = parseDiskSize(s.DiskSizeRaw)
+	s.Model = int64(aux.Model)
+	s.Memory = int64(aux.Memory)
+	s.CPUSpeed = int64(aux.CPUSpeed)
+	s.NICSpeed = int64(aux.NICSpeed)
+	if aux.Power != nil {
+		s.Power = *aux.Power
+		s.powerReported = true
+	}
+	return nil
 }
 
 // Servers represents the list of provisioned Pi servers.
@@ -98,9 +194,49 @@ type Servers struct {
 	Servers []Server `json:"servers"`
 }
 
-// List returns the list of provisioned Pi servers.
-// It does **not** return the identifiers, so it is only
-// useful for listing all servers.
+// UnmarshalJSON decodes the servers from either a list, or an object
+// keyed by identifier. Servers from an object are sorted by identifier
+// and have Identifier set from their key.
+func (s *Servers) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Servers json.RawMessage `json:"servers"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	trimmed := bytes.TrimSpace(raw.Servers)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		var list []Server
+		if len(trimmed) > 0 {
+			if err := json.Unmarshal(trimmed, &list); err != nil {
+				return err
+			}
+		}
+		s.Servers = list
+		return nil
+	}
+
+	var keyed map[string]Server
+	if err := json.Unmarshal(trimmed, &keyed); err != nil {
+		return err
+	}
+
+	s.Servers = make([]Server, 0, len(keyed))
+	for identifier, server := range keyed {
+		if server.Identifier == "" {
+			server.Identifier = identifier
+		}
+		s.Servers = append(s.Servers, server)
+	}
+	sort.Slice(s.Servers, func(i, j int) bool {
+		return s.Servers[i].Identifier < s.Servers[j].Identifier
+	})
+
+	return nil
+}
+
+// List returns the provisioned Pi servers with their identifiers.
 func (s *Service) List(ctx context.Context) ([]Server, error) {
 	var result Servers
 	_, _, err := s.GetJSON(ctx, "/pi/servers", &result, http.StatusOK)
@@ -111,19 +247,46 @@ func (s *Service) List(ctx context.Context) ([]Server, error) {
 	return result.Servers, nil
 }
 
+// FindByIP returns the Pi with the given IP address.
+// Addresses are compared as IPs, so any textual form of an IPv6
+// address matches. Returns ErrServerNotFound if no Pi has the address.
+func (s *Service) FindByIP(ctx context.Context, ip string) (Server, error) {
+	want := net.ParseIP(strings.TrimSpace(ip))
+	if want == nil {
+		return Server{}, fmt.Errorf("invalid ip address %q", ip)
+	}
+
+	servers, err := s.List(ctx)
+	if err != nil {
+		return Server{}, err
+	}
+
+	for _, server := range servers {
+		if net.ParseIP(server.IP).Equal(want) {
+			return server, nil
+		}
+	}
+
+	return Server{}, &ErrServerNotFound{IP: ip}
+}
+
 // Get retrieves details for a single Pi server by its identifier.
-// Returns ErrEmptyIdentifier if the identifier is blank.
+// Returns ErrEmptyIdentifier or ErrInvalidIdentifier if the identifier
+// is blank or malformed, see ValidateIdentifier.
 func (s *Service) Get(ctx context.Context, identifier string) (Server, error) {
-	if strings.TrimSpace(identifier) == "" {
-		return Server{}, ErrEmptyIdentifier
+	if err := ValidateIdentifier(identifier); err != nil {
+		return Server{}, err
 	}
 	url := fmt.Sprintf("/pi/servers/%s", identifier)
 
 	var result Server
-	_, _, err := s.GetJSON(ctx, url, &result)
+	_, _, err := s.GetJSON(ctx, url, &result, http.StatusOK)
 	if err != nil {
 		return Server{}, err
 	}
+	if result.Identifier == "" {
+		result.Identifier = identifier
+	}
 
 	return result, nil
 }
@@ -131,88 +294,45 @@ func (s *Service) Get(ctx context.Context, identifier string) (Server, error) {
 // CreateRequest represents the parameters for provisioning
 // a new Pi server.
 type CreateRequest struct {
-	Model      int64  `json:"model,omitempty"`
-	Memory     int64  `json:"memory,omitempty"`
-	CPUSpeed   int64  `json:"cpu_speed,omitempty"`
-	DiskSize   int64  `json:"disk,omitempty"` // intentionally different
-	SSHKey     string `json:"ssh_key,omitempty"`
-	OSImage    string `json:"os_image,omitempty"`
-	WaitForDNS bool   `json:"wait_for_dns,omitempty"`
+	Model    int64  `json:"model,omitempty"`
+	Memory   int64  `json:"memory,omitempty"`
+	CPUSpeed int64  `json:"cpu_speed,omitempty"`
+	DiskSize int64  `json:"disk,omitempty"` // intentionally different
+	SSHKey   string `json:"ssh_key,omitempty"`
+	OSImage  string `json:"os_image,omitempty"`
+	// WaitForDNS asks the API to wait for DNS before reporting the Pi
+	// as live, and makes Create resolve the FQDN itself before returning.
+	WaitForDNS bool `json:"wait_for_dns,omitempty"`
 }
 
 // Create provisions a new Pi server with the given identifier and
 // request parameters. It blocks until the server becomes live or the timeout
-// is reached. Returns ErrIdentifierConflict if the identifier is already in use.
-func (s *Service) Create(ctx context.Context, identifier string, server CreateRequest) (*Server, error) {
-	requestURL := fmt.Sprintf("/pi/servers/%s", identifier)
-
-	requestJSON, err := json.Marshal(server)
-	if err != nil {
-		return nil, err
-	}
-
-	req, err := s.NewRequest(ctx, http.MethodPost, requestURL, bytes.NewBuffer(requestJSON))
+// is reached, see CreateOptions.
+// Returns ErrIdentifierConflict if the identifier is already in use.
+// If server.WaitForDNS is set it also waits for the FQDN of the Pi to
+// resolve, see WaitForDNS. If that wait fails the Pi has still been
+// provisioned, so it is returned together with the error.
+// Use CreateAsync to provision several Pis at once.
+// See Service.CreateRetry for retrying failed provisioning requests.
+// Only the first CreateOptions is used.
+func (s *Service) Create(ctx context.Context, identifier string, server CreateRequest, opts ...CreateOptions) (*Server, error) {
+	job, err := s.CreateAsync(ctx, identifier, server)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Content-Type", "application/json")
 
-	res, err := s.Do(req)
+	created, err := job.Wait(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := s.Body(res)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
-	}
-
-	if res.StatusCode == http.StatusConflict {
-		return nil, &ErrIdentifierConflict{Identifier: identifier}
-	}
-
-	if res.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("unexpected status %d: %s", res.StatusCode, string(body))
-	}
-
-	pollURL := res.Header.Get("Location")
-	if pollURL == "" {
-		return nil, fmt.Errorf("missing header location for polling")
-	}
-
-	isPiReady := func(data map[string]any, identifier string) (string, bool) {
-		if status, ok := data["status"].(string); ok && status == "live" {
-			return fmt.Sprintf("/pi/servers/%s", identifier), true
+	if server.WaitForDNS {
+		if err := s.WaitForDNS(ctx, *created, WaitOptions{}); err != nil {
+			return created, err
 		}
-		return "", false
-	}
-
-	serverURL, err := s.PollProvisioning(ctx, pollURL, 5*time.Minute, identifier, isPiReady)
-	if err != nil {
-		return nil, err
 	}
 
-	serverRes, err := s.BaseService.Get(ctx, serverURL)
-	if err != nil {
-		return nil, err
-	}
-
-	serverBody, err := s.Body(serverRes)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected status %s", string(serverBody))
-	}
-
-	if serverRes.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch server info: %s", string(serverBody))
-	}
-
-	var created Server
-	err = json.Unmarshal(serverBody, &created)
-	if err != nil {
-		return nil, err
-	}
-
-	return &created, nil
+	return created, nil
 }
 
 type UpdateSSHKeyRequest struct {
@@ -227,8 +347,8 @@ type UpdateSSHKeyResponse struct {
 // /root/.ssh/authorized_keys with the provided key.
 // It returns the contents of that file.
 func (s *Service) UpdateSSHKey(ctx context.Context, identifier string, req UpdateSSHKeyRequest) (UpdateSSHKeyResponse, error) {
-	if strings.TrimSpace(identifier) == "" {
-		return UpdateSSHKeyResponse{}, ErrEmptyIdentifier
+	if err := ValidateIdentifier(identifier); err != nil {
+		return UpdateSSHKeyResponse{}, err
 	}
 
 	if strings.TrimSpace(req.SSHKey) == "" {
@@ -246,11 +366,12 @@ func (s *Service) UpdateSSHKey(ctx context.Context, identifier string, req Updat
 }
 
 // Delete removes the Pi server with the given identifier.
-// Returns ErrEmptyIdentifier if the identifier is blank.
+// Returns ErrEmptyIdentifier or ErrInvalidIdentifier if the identifier
+// is blank or malformed, see ValidateIdentifier.
 // Considers a 404 as a successful deletion.
 func (s *Service) Delete(ctx context.Context, identifier string) error {
-	if strings.TrimSpace(identifier) == "" {
-		return ErrEmptyIdentifier
+	if err := ValidateIdentifier(identifier); err != nil {
+		return err
 	}
 
 	url := fmt.Sprintf("/pi/servers/%s", identifier)
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/paultibbetts/mythicbeasts-client-go"
 	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
@@ -61,6 +63,51 @@ func TestRaspberryPis_ListModels_OK(t *testing.T) {
 	}
 }
 
+func TestRaspberryPis_ListModels_StrictDecoding(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/models", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"models":[{"model":4,"memory":4096,"ram_mb":4096}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := mythicbeasts.NewClient("", "", mythicbeasts.WithStrictDecoding())
+	c.Pi().BaseURL = srv.URL
+
+	_, err := c.Pi().ListModels(testContext())
+	var malformed *mythicbeasts.ErrMalformedResponse
+	if !errors.As(err, &malformed) || malformed.Field != "models[0].ram_mb" {
+		t.Fatalf("err=%v, want ErrMalformedResponse for models[0].ram_mb", err)
+	}
+}
+
+func TestRaspberryPis_ListModels_Availability(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/models", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"models":[
+			{"model":3,"memory":1024,"price":750},
+			{"model":4,"memory":4096,"price":1250,"available":true,"stock":0},
+			{"model":4,"memory":8192,"price":1750,"available":true,"stock":12}]}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	models, err := c.Pi().ListModels(testContext())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(models) != 3 || models[1].Price != 1250 || *models[2].Stock != 12 {
+		t.Fatalf("models=%+v", models)
+	}
+	for i, want := range []bool{true, false, true} {
+		if models[i].InStock() != want {
+			t.Fatalf("models[%d].InStock()=%v, want %v", i, !want, want)
+		}
+	}
+}
+
 func TestRaspberryPis_ListModels_BadJSON(t *testing.T) {
 	t.Parallel()
 	mux := http.NewServeMux()
@@ -167,8 +214,8 @@ func TestRaspberryPis_List(t *testing.T) {
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(piapi.Servers{
 			Servers: []piapi.Server{
-				{IP: "12.34.56.78", SSHPort: 22, DiskSize: "1", InitializedKeys: false, Location: "eu", Model: 3, Memory: 1024, CPUSpeed: 1200, NICSpeed: 100},
-				{IP: "21.43.65.87", SSHPort: 2222, DiskSize: "2", InitializedKeys: false, Location: "lon", Model: 4, Memory: 2048, CPUSpeed: 1300, NICSpeed: 1000},
+				{IP: "12.34.56.78", SSHPort: 22, DiskSize: 1, InitializedKeys: false, Location: "eu", Model: 3, Memory: 1024, CPUSpeed: 1200, NICSpeed: 100},
+				{IP: "21.43.65.87", SSHPort: 2222, DiskSize: 2, InitializedKeys: false, Location: "lon", Model: 4, Memory: 2048, CPUSpeed: 1300, NICSpeed: 1000},
 			},
 		})
 
@@ -193,6 +240,51 @@ func TestRaspberryPis_List(t *testing.T) {
 	}
 }
 
+func TestRaspberryPis_List_Keyed(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"servers":{
+			"web2":{"ip":"2a00::2","ssh_port":5002,"model":4},
+			"web1":{"ip":"2a00::1","ssh_port":5001,"model":4}}}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	pis, err := c.Pi().List(testContext())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(pis) != 2 || pis[0].Identifier != "web1" || pis[0].SSHPort != 5001 || pis[1].Identifier != "web2" {
+		t.Fatalf("pis=%+v", pis)
+	}
+}
+
+func TestRaspberryPis_FindByIP(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"servers":{
+			"web1":{"ip":"2a00:1098:8:1::1","ssh_port":5001},
+			"web2":{"ip":"2a00:1098:8:1::2","ssh_port":5002}}}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	server, err := c.Pi().FindByIP(testContext(), "2a00:1098:0008:0001:0000:0000:0000:0002")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Identifier != "web2" {
+		t.Fatalf("server=%+v, want web2", server)
+	}
+
+	var notFound *piapi.ErrServerNotFound
+	if _, err := c.Pi().FindByIP(testContext(), "2a00:1098:8:1::3"); !errors.As(err, &notFound) {
+		t.Fatalf("err=%v, want ErrServerNotFound", err)
+	}
+}
+
 func TestRaspberryPis_List_BadJSON(t *testing.T) {
 	t.Parallel()
 	mux := http.NewServeMux()
@@ -233,7 +325,7 @@ func TestRaspberryPis_Get(t *testing.T) {
 		}
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(piapi.Server{
-			IP: "12.34.56.78", SSHPort: 22, DiskSize: "1", InitializedKeys: false, Location: "eu", Model: 3, Memory: 1024, CPUSpeed: 1200, NICSpeed: 100,
+			IP: "12.34.56.78", SSHPort: 22, DiskSize: 1, InitializedKeys: false, Location: "eu", Model: 3, Memory: 1024, CPUSpeed: 1200, NICSpeed: 100,
 		})
 
 	})
@@ -246,7 +338,7 @@ func TestRaspberryPis_Get(t *testing.T) {
 		t.Fatalf("err: %v", err)
 	}
 
-	if pi.IP != "12.34.56.78" || pi.SSHPort != 22 || pi.DiskSize != "1" || pi.InitializedKeys != false || pi.Location != "eu" || pi.Model != 3 || pi.Memory != 1024 || pi.CPUSpeed != 1200 || pi.NICSpeed != 100 {
+	if pi.Identifier != "1" || pi.IP != "12.34.56.78" || pi.SSHPort != 22 || pi.DiskSize != 1 || pi.InitializedKeys != false || pi.Location != "eu" || pi.Model != 3 || pi.Memory != 1024 || pi.CPUSpeed != 1200 || pi.NICSpeed != 100 {
 		t.Fatalf("pis[0]=%+v", pi)
 	}
 }
@@ -298,7 +390,7 @@ func TestRaspberryPis_Create_Success(t *testing.T) {
 			w.WriteHeader(http.StatusAccepted)
 		case http.MethodGet:
 			_ = json.NewEncoder(w).Encode(piapi.Server{
-				IP: "12.34.56.78", SSHPort: 22, DiskSize: "1", InitializedKeys: false, Location: "eu", Model: 3, Memory: 1024, CPUSpeed: 1200, NICSpeed: 100,
+				IP: "12.34.56.78", SSHPort: 22, DiskSize: 1, InitializedKeys: false, Location: "eu", Model: 3, Memory: 1024, CPUSpeed: 1200, NICSpeed: 100,
 			})
 		default:
 			t.Fatalf("unexpected method %s", r.Method)
@@ -317,7 +409,7 @@ func TestRaspberryPis_Create_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("create pi error: %v", err)
 	}
-	if got == nil || got.IP != "12.34.56.78" || got.SSHPort != 22 || got.DiskSize != "1" || got.InitializedKeys != false || got.Location != "eu" || got.Model != 3 || got.Memory != 1024 || got.CPUSpeed != 1200 || got.NICSpeed != 100 {
+	if got == nil || got.IP != "12.34.56.78" || got.SSHPort != 22 || got.DiskSize != 1 || got.InitializedKeys != false || got.Location != "eu" || got.Model != 3 || got.Memory != 1024 || got.CPUSpeed != 1200 || got.NICSpeed != 100 {
 		t.Fatalf("got=%+v", got)
 	}
 }
@@ -345,6 +437,108 @@ func TestRaspberryPis_Create_Conflict(t *testing.T) {
 	}
 }
 
+func TestRaspberryPis_Create_RetriesBadGateway(t *testing.T) {
+	t.Parallel()
+	const id = "retry-pi"
+	var posts, gets int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/"+id, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			if atomic.AddInt32(&posts, 1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Header().Set("Location", "/poll/"+id)
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			if atomic.AddInt32(&gets, 1) == 1 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"ip":"2a00::1","ssh_port":5000}`))
+		}
+	})
+	mux.HandleFunc("/poll/"+id, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/pi/servers/"+id)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.Pi().CreateRetry = piapi.RetryPolicy{Attempts: 2, Backoff: time.Millisecond}
+
+	got, err := c.Pi().Create(testContext(), id, piapi.CreateRequest{})
+	if err != nil {
+		t.Fatalf("create pi error: %v", err)
+	}
+	if got.SSHPort != 5000 {
+		t.Fatalf("got=%+v", got)
+	}
+	if atomic.LoadInt32(&posts) != 2 {
+		t.Fatalf("posts=%d, want 2", posts)
+	}
+}
+
+func TestRaspberryPis_Create_RetryFindsExisting(t *testing.T) {
+	t.Parallel()
+	var posts int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/made", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			atomic.AddInt32(&posts, 1)
+			w.WriteHeader(http.StatusGatewayTimeout)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"ip":"2a00::1"}`))
+		}
+	})
+
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.Pi().CreateRetry = piapi.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+	_, err := c.Pi().Create(testContext(), "made", piapi.CreateRequest{})
+	var conflict *piapi.ErrIdentifierConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("want ErrIdentifierConflict, got %T: %v", err, err)
+	}
+	if atomic.LoadInt32(&posts) != 1 {
+		t.Fatalf("posts=%d, want 1", posts)
+	}
+}
+
+func TestRaspberryPis_Create_OutOfStockNotRetried(t *testing.T) {
+	t.Parallel()
+	var posts, gets int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/nostock", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			atomic.AddInt32(&posts, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":"No servers of the requested specification are available"}`))
+		case http.MethodGet:
+			atomic.AddInt32(&gets, 1)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.Pi().CreateRetry = piapi.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+	_, err := c.Pi().Create(testContext(), "nostock", piapi.CreateRequest{Model: 4})
+	var stock *piapi.ErrOutOfStock
+	if !errors.As(err, &stock) {
+		t.Fatalf("want ErrOutOfStock, got %T: %v", err, err)
+	}
+	if atomic.LoadInt32(&posts) != 1 || atomic.LoadInt32(&gets) != 0 {
+		t.Fatalf("posts=%d gets=%d, want a single POST", posts, gets)
+	}
+}
+
 func TestRaspberryPis_Create_MissingLocation(t *testing.T) {
 	t.Parallel()
 	mux := http.NewServeMux()
@@ -530,3 +724,20 @@ func TestRaspBerryPis_Delete_NetworkError(t *testing.T) {
 		t.Fatalf("expected network error, got nil")
 	}
 }
+
+func TestUpdateSSHKeyRequest_SetSSHKeys(t *testing.T) {
+	t.Parallel()
+	key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC5cSqQNVmTIWz9901r8HB+DiwmnFYRWYXChyqigkzAA"
+
+	var req piapi.UpdateSSHKeyRequest
+	if err := req.SetSSHKeys(piapi.SSHKeys{key, key + " again"}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req.SSHKey != key {
+		t.Fatalf("ssh_key=%q, want %q", req.SSHKey, key)
+	}
+
+	if err := req.SetSSHKeys(piapi.SSHKeys{"ssh-rsa AAAAB..."}); err == nil {
+		t.Fatalf("expected error for malformed key")
+	}
+}
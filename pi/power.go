@@ -0,0 +1,96 @@
+package pi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultRebootGracePeriod is the default wait time after a reboot request.
+const DefaultRebootGracePeriod = 2 * time.Minute
+
+// RebootResponse represents the response from a reboot operation.
+type RebootResponse struct {
+	Message string `json:"message"`
+}
+
+// Reboot power cycles the Pi with the given identifier.
+// The call returns once the reboot has been initiated.
+// Returns ErrEmptyIdentifier or ErrInvalidIdentifier if the identifier
+// is blank or malformed, see ValidateIdentifier.
+func (s *Service) Reboot(ctx context.Context, identifier string) (RebootResponse, error) {
+	if err := ValidateIdentifier(identifier); err != nil {
+		return RebootResponse{}, err
+	}
+
+	url := fmt.Sprintf("/pi/servers/%s/reboot", identifier)
+
+	var result RebootResponse
+	if _, _, err := s.DoJSON(ctx, http.MethodPost, url, nil, &result, http.StatusOK, http.StatusAccepted); err != nil {
+		return RebootResponse{}, err
+	}
+
+	return result, nil
+}
+
+// RebootWithGrace reboots the Pi and waits for a grace period
+// to give it time to come back up.
+// If gracePeriod <= 0, DefaultRebootGracePeriod is used.
+func (s *Service) RebootWithGrace(ctx context.Context, identifier string, gracePeriod time.Duration) (RebootResponse, error) {
+	resp, err := s.Reboot(ctx, identifier)
+	if err != nil {
+		return RebootResponse{}, err
+	}
+
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultRebootGracePeriod
+	}
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return RebootResponse{}, ctx.Err()
+	case <-timer.C:
+		return resp, nil
+	}
+}
+
+// PowerState represents whether a Pi is powered on.
+type PowerState string
+
+const (
+	PowerStateOn      PowerState = "on"
+	PowerStateOff     PowerState = "off"
+	PowerStateUnknown PowerState = "unknown"
+)
+
+// PowerState reports the power state of the Pi. A booting Pi is on.
+// It is PowerStateUnknown if the API did not report the power state,
+// or the Pi has not finished provisioning.
+func (s Server) PowerState() PowerState {
+	if s.Status != "" && parseProvisioningState(s.Status) != ProvisioningLive {
+		return PowerStateUnknown
+	}
+	switch {
+	case bool(s.IsBooting), bool(s.Power):
+		return PowerStateOn
+	case s.powerReported:
+		return PowerStateOff
+	default:
+		return PowerStateUnknown
+	}
+}
+
+// GetPowerStatus retrieves the current power state of the Pi.
+// Returns ErrInvalidIdentifier if the identifier is malformed.
+func (s *Service) GetPowerStatus(ctx context.Context, identifier string) (PowerState, error) {
+	server, err := s.Get(ctx, identifier)
+	if err != nil {
+		return PowerStateUnknown, err
+	}
+
+	return server.PowerState(), nil
+}
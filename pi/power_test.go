@@ -0,0 +1,91 @@
+package pi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestRaspberryPis_Reboot(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1/reboot", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("method=%s, want POST", r.Method)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"message":"Reboot requested"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	resp, err := c.Pi().Reboot(testContext(), "pi1")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp.Message != "Reboot requested" {
+		t.Fatalf("message=%q", resp.Message)
+	}
+
+	if _, err := c.Pi().Reboot(testContext(), " "); !errors.Is(err, piapi.ErrEmptyIdentifier) {
+		t.Fatalf("err=%v, want ErrEmptyIdentifier", err)
+	}
+}
+
+func TestRaspberryPis_RebootWithGrace(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1/reboot", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	if _, err := c.Pi().RebootWithGrace(testContext(), "pi1", time.Millisecond); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(testContext())
+	cancel()
+	if _, err := c.Pi().RebootWithGrace(ctx, "pi1", time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err=%v, want context.Canceled", err)
+	}
+}
+
+func TestRaspberryPis_GetPowerStatus(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	for id, body := range map[string]string{
+		"on":      `{"status":"live","power":true}`,
+		"booting": `{"status":"live","power":false,"is_booting":true}`,
+		"off":     `{"status":"live","power":"off"}`,
+		"missing": `{"status":"live"}`,
+		"new":     `{"status":"installing","power":false}`,
+	} {
+		mux.HandleFunc("/pi/servers/"+id, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		})
+	}
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	for id, want := range map[string]piapi.PowerState{
+		"on":      piapi.PowerStateOn,
+		"booting": piapi.PowerStateOn,
+		"off":     piapi.PowerStateOff,
+		"missing": piapi.PowerStateUnknown,
+		"new":     piapi.PowerStateUnknown,
+	} {
+		got, err := c.Pi().GetPowerStatus(testContext(), id)
+		if err != nil {
+			t.Fatalf("%s: err: %v", id, err)
+		}
+		if got != want {
+			t.Fatalf("%s: state=%q, want %q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package pi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
+
+const (
+	// DefaultCreateTimeout is the default time to wait for a Pi to be provisioned.
+	DefaultCreateTimeout = 5 * time.Minute
+	// DefaultCancelTimeout is the time allowed for cancelling a job
+	// after the wait for it was aborted. See CreateOptions.CancelOnAbort.
+	DefaultCancelTimeout = 30 * time.Second
+)
+
+// ProvisioningJob tracks a Pi being provisioned by CreateAsync.
+type ProvisioningJob struct {
+	// Identifier is the identifier of the Pi being provisioned.
+	Identifier string
+	// PollURL is the URL polled for the provisioning status.
+	PollURL string
+
+	service *Service
+}
+
+// ProvisioningState is the stage a Pi has reached in provisioning.
+type ProvisioningState string
+
+const (
+	ProvisioningQueued     ProvisioningState = "queued"
+	ProvisioningInstalling ProvisioningState = "installing"
+	ProvisioningBooting    ProvisioningState = "booting"
+	ProvisioningLive       ProvisioningState = "live"
+	ProvisioningFailed     ProvisioningState = "failed"
+	ProvisioningCancelled  ProvisioningState = "cancelled"
+)
+
+// parseProvisioningState maps a status reported by the API to a
+// ProvisioningState. Unrecognised statuses are returned as they are.
+func parseProvisioningState(status string) ProvisioningState {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "queued", "pending", "waiting":
+		return ProvisioningQueued
+	case "installing", "provisioning", "imaging":
+		return ProvisioningInstalling
+	case "booting", "starting":
+		return ProvisioningBooting
+	case "live", "running", "ready":
+		return ProvisioningLive
+	case "failed", "error":
+		return ProvisioningFailed
+	case "cancelled", "canceled", "aborted":
+		return ProvisioningCancelled
+	default:
+		return ProvisioningState(status)
+	}
+}
+
+// ProvisioningStatus represents a single poll of a provisioning job.
+type ProvisioningStatus struct {
+	// Status is the status reported by the API, e.g. "live".
+	Status string
+	// State is Status mapped to a known provisioning stage.
+	State ProvisioningState
+	// Done reports whether the Pi has finished provisioning.
+	Done bool
+	// Reason explains why provisioning failed, if it did.
+	Reason string
+	// Message is any progress text reported by the API.
+	Message string
+	// QueuePosition is the position of the job in the provisioning
+	// queue, or 0 if the API did not report one.
+	QueuePosition int
+	// ETA is the estimated time until the Pi is live,
+	// or 0 if the API did not report one.
+	ETA time.Duration
+	// Data is the decoded poll response, if there was one.
+	Data map[string]any
+}
+
+// CreateOptions controls how Create and ProvisioningJob.Wait
+// wait for a Pi to be provisioned.
+type CreateOptions struct {
+	// Timeout bounds the wait for provisioning.
+	// If Timeout <= 0, DefaultCreateTimeout is used.
+	Timeout time.Duration
+	// PollInterval is the wait between polls.
+	// If PollInterval <= 0, the client's PollInterval is used.
+	PollInterval time.Duration
+	// OnProgress, if set, is called with the status of each poll,
+	// including polls answered with 202 Accepted while the job is queued,
+	// so long waits for a model in short supply can be reported.
+	OnProgress func(ProvisioningStatus)
+	// CancelOnAbort cancels the job server-side if ctx is cancelled
+	// while waiting, e.g. on Ctrl-C, so an abandoned Pi is not left
+	// in the queue or billed. See ProvisioningJob.Cancel.
+	CancelOnAbort bool
+}
+
+// newProvisioningStatus builds a ProvisioningStatus from a status
+// reported by the API and the decoded response it came in.
+func newProvisioningStatus(status string, data map[string]any) ProvisioningStatus {
+	state := parseProvisioningState(status)
+	result := ProvisioningStatus{Status: status, State: state, Done: state == ProvisioningLive, Data: data}
+	if position, ok := dataNumber(data, "queue_position", "position"); ok {
+		result.QueuePosition = int(position)
+	}
+	if eta, ok := dataNumber(data, "eta", "eta_seconds"); ok {
+		result.ETA = time.Duration(eta * float64(time.Second))
+	}
+	for _, key := range []string{"message", "status_text", "progress"} {
+		if message, ok := data[key].(string); ok && message != "" {
+			result.Message = message
+			break
+		}
+	}
+	if state == ProvisioningFailed {
+		for _, key := range []string{"reason", "error", "message"} {
+			if reason, ok := data[key].(string); ok && reason != "" {
+				result.Reason = reason
+				break
+			}
+		}
+	}
+	return result
+}
+
+// dataNumber returns the first of keys in data holding a number,
+// which may be sent as a JSON number or a numeric string.
+func dataNumber(data map[string]any, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		switch v := data[key].(type) {
+		case float64:
+			return v, true
+		case string:
+			if n, err := strconv.ParseFloat(v, 64); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// progressStatus builds a ProvisioningStatus from a poll response
+// observed while waiting for provisioning.
+func progressStatus(event transport.PollEvent) ProvisioningStatus {
+	status, _ := event.Data["status"].(string)
+	switch event.StatusCode {
+	case http.StatusSeeOther:
+		status = string(ProvisioningLive)
+	case http.StatusAccepted:
+		if status == "" {
+			status = string(ProvisioningQueued)
+		}
+	}
+	return newProvisioningStatus(status, event.Data)
+}
+
+// CreateAsync requests a new Pi with the given identifier and request
+// parameters and returns once the API has accepted the request.
+// Use the returned job to wait for, check or cancel provisioning, which
+// lets many Pis be provisioned at once.
+//
+// Returns ErrIdentifierConflict if the identifier is already in use,
+// ErrInvalidIdentifier if it is malformed, see ValidateIdentifier,
+// ErrOutOfStock if no Pis of the requested model are available, and
+// ErrPaymentRequired or ErrQuotaExceeded if the account cannot take it.
+// See Service.CreateRetry for retrying failed provisioning requests.
+func (s *Service) CreateAsync(ctx context.Context, identifier string, server CreateRequest) (*ProvisioningJob, error) {
+	if err := ValidateIdentifier(identifier); err != nil {
+		return nil, err
+	}
+
+	requestURL := fmt.Sprintf("/pi/servers/%s", identifier)
+
+	res, body, err := s.PostCreate(ctx, requestURL, server, s.CreateRetry)
+	if errors.Is(err, transport.ErrExists) {
+		return nil, &ErrIdentifierConflict{Identifier: identifier}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusConflict {
+		return nil, &ErrIdentifierConflict{Identifier: identifier}
+	}
+
+	if res.StatusCode != http.StatusAccepted {
+		return nil, createError(s.NewAPIError(res, body), server.Model)
+	}
+
+	pollURL := res.Header.Get("Location")
+	if pollURL == "" {
+		return nil, fmt.Errorf("missing header location for polling")
+	}
+
+	return &ProvisioningJob{Identifier: identifier, PollURL: pollURL, service: s}, nil
+}
+
+// Wait blocks until the Pi is live or the timeout is reached,
+// then returns the provisioned server.
+// Returns ProvisioningError if provisioning fails,
+// ErrProvisioningCancelled if the job is cancelled, and
+// ErrWaitTimeout if the Pi is not live before the timeout.
+// Only the first CreateOptions is used.
+func (j *ProvisioningJob) Wait(ctx context.Context, opts ...CreateOptions) (*Server, error) {
+	var o CreateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCreateTimeout
+	}
+
+	isPiReady := func(data map[string]any, identifier string) (string, bool) {
+		if status, ok := data["status"].(string); ok && parseProvisioningState(status) == ProvisioningLive {
+			return fmt.Sprintf("/pi/servers/%s", identifier), true
+		}
+		return "", false
+	}
+
+	pollCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	start := time.Now()
+	cancelled := false
+	var history []ProvisioningStatus
+	observe := func(event transport.PollEvent) {
+		status := progressStatus(event)
+		history = append(history, status)
+		if o.OnProgress != nil {
+			o.OnProgress(status)
+		}
+		if status.State == ProvisioningCancelled {
+			cancelled = true
+			stop()
+		}
+	}
+
+	poll := transport.PollOptions{Interval: o.PollInterval, Observer: observe}
+	serverURL, err := j.service.PollProvisioning(pollCtx, j.PollURL, timeout, j.Identifier, poll, isPiReady)
+	if cancelled {
+		return nil, &ErrProvisioningCancelled{Identifier: j.Identifier}
+	}
+	if errors.Is(err, transport.ErrPollTimeout) {
+		timeoutErr := &ErrWaitTimeout{Identifier: j.Identifier, Want: string(ProvisioningLive)}
+		if len(history) > 0 {
+			timeoutErr.Last = history[len(history)-1].Status
+		}
+		return nil, timeoutErr
+	}
+	var failed *transport.ErrPollFailed
+	if errors.As(err, &failed) {
+		provErr := &ProvisioningError{Identifier: j.Identifier, Phase: ProvisioningQueued, Elapsed: time.Since(start), Err: err}
+		if len(history) > 0 {
+			provErr.Phase = history[len(history)-1].State
+		}
+		status := failedStatus([]byte(failed.Body))
+		provErr.Reason = status.Reason
+		provErr.Statuses = append(history, status)
+		return nil, provErr
+	}
+	if err != nil && ctx.Err() != nil && o.CancelOnAbort {
+		cancelCtx, done := context.WithTimeout(context.WithoutCancel(ctx), DefaultCancelTimeout)
+		defer done()
+		if cancelErr := j.Cancel(cancelCtx); cancelErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("cancel provisioning: %w", cancelErr))
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var created Server
+	if _, _, err := j.service.GetJSON(ctx, serverURL, &created, http.StatusOK); err != nil {
+		return nil, fmt.Errorf("failed to fetch server info: %w", err)
+	}
+	if created.Identifier == "" {
+		created.Identifier = j.Identifier
+	}
+
+	return &created, nil
+}
+
+// Status polls the job once and reports the provisioning status.
+// A failed job is reported with the ProvisioningFailed state, not an error.
+func (j *ProvisioningJob) Status(ctx context.Context) (ProvisioningStatus, error) {
+	return j.service.GetProvisioningStatus(ctx, j.PollURL)
+}
+
+// failedStatus builds the ProvisioningStatus of a failed poll response.
+// If the body gives no reason the whole body is used.
+func failedStatus(body []byte) ProvisioningStatus {
+	var data map[string]any
+	_ = json.Unmarshal(body, &data)
+	result := newProvisioningStatus(string(ProvisioningFailed), data)
+	if result.Reason == "" {
+		result.Reason = strings.TrimSpace(string(body))
+	}
+	return result
+}
+
+// pollStatus interprets a response from a provisioning poll URL.
+func (s *Service) pollStatus(res *http.Response, body []byte) (ProvisioningStatus, error) {
+	var data map[string]any
+	_ = json.Unmarshal(body, &data)
+	status, _ := data["status"].(string)
+
+	switch res.StatusCode {
+	case http.StatusSeeOther, http.StatusAccepted:
+		return progressStatus(transport.PollEvent{StatusCode: res.StatusCode, Data: data}), nil
+	case http.StatusOK:
+		if res.Header.Get("Location") != "" {
+			return newProvisioningStatus(string(ProvisioningLive), data), nil
+		}
+		if data == nil {
+			return ProvisioningStatus{}, fmt.Errorf("could not decode provisioning status: %s", string(body))
+		}
+		return newProvisioningStatus(status, data), nil
+	case http.StatusInternalServerError:
+		return failedStatus(body), nil
+	default:
+		return ProvisioningStatus{}, s.NewAPIError(res, body)
+	}
+}
+
+// GetProvisioningStatus reports how far provisioning has got.
+//
+// ref is either the PollURL of a ProvisioningJob, or the identifier
+// of a Pi. For an identifier the Pi itself is queried, and a Pi that
+// does not report a status is assumed to be live.
+// A failed job is reported with the ProvisioningFailed state, not an error.
+func (s *Service) GetProvisioningStatus(ctx context.Context, ref string) (ProvisioningStatus, error) {
+	if strings.TrimSpace(ref) == "" {
+		return ProvisioningStatus{}, ErrEmptyIdentifier
+	}
+
+	pollURL := ref
+	if !strings.HasPrefix(ref, "/") && !strings.Contains(ref, "://") {
+		pollURL = fmt.Sprintf("/pi/servers/%s", ref)
+	}
+
+	res, err := s.BaseService.Get(ctx, pollURL)
+	if err != nil {
+		return ProvisioningStatus{}, err
+	}
+
+	body, err := s.Body(res)
+	if err != nil {
+		return ProvisioningStatus{}, err
+	}
+
+	if pollURL != ref && res.StatusCode == http.StatusOK {
+		var data map[string]any
+		if err := json.Unmarshal(body, &data); err != nil {
+			return ProvisioningStatus{}, err
+		}
+		status, _ := data["status"].(string)
+		if status == "" {
+			status = string(ProvisioningLive)
+		}
+		return newProvisioningStatus(status, data), nil
+	}
+
+	return s.pollStatus(res, body)
+}
+
+// Cancel aborts provisioning server-side.
+//
+// It cancels the queued job by sending DELETE to the poll URL.
+// If the API does not support that, or the job has already finished,
+// it returns ErrCancelUnsupported and never deletes the Pi itself.
+// A cancelled job reports the ProvisioningCancelled state and
+// Wait returns ErrProvisioningCancelled.
+func (j *ProvisioningJob) Cancel(ctx context.Context) error {
+	_, _, err := j.service.DoJSON(ctx, http.MethodDelete, j.PollURL, nil, nil,
+		http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+
+	var apiErr *transport.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return fmt.Errorf("%w: %w", ErrCancelUnsupported, err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,426 @@
+package pi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func provisioningMux(t *testing.T, id string, polls *int) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/"+id, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/queue/pi/"+id)
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"ip":"2a00:1098:8:1::1","ssh_port":5123}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/queue/pi/"+id, func(w http.ResponseWriter, r *http.Request) {
+		*polls++
+		if *polls < 2 {
+			_, _ = w.Write([]byte(`{"status":"installing"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"live"}`))
+	})
+	return mux
+}
+
+func TestRaspberryPis_CreateAsync_StatusAndWait(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	c, srv := newTestClient(t, provisioningMux(t, "async", &polls))
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	job, err := c.Pi().CreateAsync(testContext(), "async", piapi.CreateRequest{Model: 4})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if job.Identifier != "async" || job.PollURL != "/queue/pi/async" {
+		t.Fatalf("job=%+v", job)
+	}
+
+	status, err := job.Status(testContext())
+	if err != nil {
+		t.Fatalf("status err: %v", err)
+	}
+	if status.Status != "installing" || status.Done {
+		t.Fatalf("status=%+v", status)
+	}
+
+	server, err := job.Wait(testContext())
+	if err != nil {
+		t.Fatalf("wait err: %v", err)
+	}
+	if server.SSHPort != 5123 {
+		t.Fatalf("server=%+v", server)
+	}
+}
+
+func TestRaspberryPis_CreateAsync_EmptyIdentifier(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, http.NewServeMux())
+	defer srv.Close()
+
+	if _, err := c.Pi().CreateAsync(testContext(), " ", piapi.CreateRequest{}); !errors.Is(err, piapi.ErrEmptyIdentifier) {
+		t.Fatalf("err=%v, want ErrEmptyIdentifier", err)
+	}
+}
+
+func TestRaspberryPis_ProvisioningJob_Cancel(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		queueStatus int
+		want        string
+		wantErr     error
+	}{
+		{name: "cancels queued job", queueStatus: http.StatusNoContent, want: "DELETE /queue/pi/job"},
+		{name: "unsupported", queueStatus: http.StatusMethodNotAllowed, want: "DELETE /queue/pi/job", wantErr: piapi.ErrCancelUnsupported},
+		{name: "already finished", queueStatus: http.StatusNotFound, want: "DELETE /queue/pi/job", wantErr: piapi.ErrCancelUnsupported},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/queue/pi/job", func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				w.WriteHeader(tc.queueStatus)
+			})
+			mux.HandleFunc("/pi/servers/job", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.Header().Set("Location", "/queue/pi/job")
+					w.WriteHeader(http.StatusAccepted)
+					return
+				}
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			})
+			c, srv := newTestClient(t, mux)
+			defer srv.Close()
+
+			job, err := c.Pi().CreateAsync(testContext(), "job", piapi.CreateRequest{})
+			if err != nil {
+				t.Fatalf("err: %v", err)
+			}
+			if err := job.Cancel(testContext()); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("err=%v, want %v", err, tc.wantErr)
+			}
+			if got := strings.Join(calls, ","); got != tc.want {
+				t.Fatalf("calls=%s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRaspberryPis_GetProvisioningStatus(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/queue/pi/queued", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/queue/pi/booting", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"Booting"}`))
+	})
+	mux.HandleFunc("/queue/pi/failed", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"status":"failed","error":"No Pi 4s available"}`))
+	})
+	mux.HandleFunc("/pi/servers/pi1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ip":"2a00:1098:8:1::1"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	for ref, want := range map[string]piapi.ProvisioningState{
+		"/queue/pi/queued":  piapi.ProvisioningQueued,
+		"/queue/pi/booting": piapi.ProvisioningBooting,
+		"/queue/pi/failed":  piapi.ProvisioningFailed,
+		"pi1":               piapi.ProvisioningLive,
+	} {
+		status, err := c.Pi().GetProvisioningStatus(testContext(), ref)
+		if err != nil {
+			t.Fatalf("%s: err: %v", ref, err)
+		}
+		if status.State != want {
+			t.Fatalf("%s: state=%q, want %q", ref, status.State, want)
+		}
+		if status.Done != (want == piapi.ProvisioningLive) {
+			t.Fatalf("%s: done=%v", ref, status.Done)
+		}
+	}
+
+	status, _ := c.Pi().GetProvisioningStatus(testContext(), "/queue/pi/failed")
+	if status.Reason != "No Pi 4s available" {
+		t.Fatalf("reason=%q", status.Reason)
+	}
+}
+
+func TestRaspberryPis_CreateAsync_OutOfStock(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/nostock", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"No servers of the requested specification are available"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.Pi().CreateAsync(testContext(), "nostock", piapi.CreateRequest{Model: 4})
+	var stock *piapi.ErrOutOfStock
+	if !errors.As(err, &stock) || stock.Model != 4 {
+		t.Fatalf("err=%v, want ErrOutOfStock", err)
+	}
+	var apiErr *mythicbeasts.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err=%v, want wrapped APIError", err)
+	}
+}
+
+func TestRaspberryPis_Create_OnProgressReportsQueue(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/queued", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set("Location", "/queue/pi/queued")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ip":"2a00:1098:8:1::1","ssh_port":5123}`))
+	})
+	mux.HandleFunc("/queue/pi/queued", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch polls {
+		case 1:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"status":"queued","queue_position":"3","eta":90,"message":"Waiting for a model 4"}`))
+		case 2:
+			_, _ = w.Write([]byte(`{"status":"installing"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"live"}`))
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	var seen []piapi.ProvisioningStatus
+	_, err := c.Pi().Create(testContext(), "queued", piapi.CreateRequest{Model: 4}, piapi.CreateOptions{
+		OnProgress: func(status piapi.ProvisioningStatus) {
+			seen = append(seen, status)
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("progress=%+v, want 3 polls", seen)
+	}
+	first := seen[0]
+	if first.State != piapi.ProvisioningQueued || first.QueuePosition != 3 || first.ETA != 90*time.Second || first.Message != "Waiting for a model 4" {
+		t.Fatalf("first=%+v, want queue details", first)
+	}
+	if seen[1].State != piapi.ProvisioningInstalling || !seen[2].Done {
+		t.Fatalf("progress=%+v", seen)
+	}
+}
+
+func TestRaspberryPis_Create_Cancelled(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/queue/pi/gone")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/queue/pi/gone", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"cancelled"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	_, err := c.Pi().Create(testContext(), "gone", piapi.CreateRequest{Model: 4})
+	var cancelled *piapi.ErrProvisioningCancelled
+	if !errors.As(err, &cancelled) || cancelled.Identifier != "gone" {
+		t.Fatalf("err=%v, want ErrProvisioningCancelled", err)
+	}
+}
+
+func TestRaspberryPis_Create_CancelOnAbort(t *testing.T) {
+	t.Parallel()
+	var deleted atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/abort", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/queue/pi/abort")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/queue/pi/abort", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted.Store(true)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(testContext())
+	defer cancel()
+	_, err := c.Pi().Create(ctx, "abort", piapi.CreateRequest{Model: 4}, piapi.CreateOptions{
+		CancelOnAbort: true,
+		OnProgress:    func(piapi.ProvisioningStatus) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err=%v, want context.Canceled", err)
+	}
+	if !deleted.Load() {
+		t.Fatal("expected queued job to be cancelled")
+	}
+}
+
+func TestRaspberryPis_Create_WithOptions(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/slow", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/queue/pi/slow")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/queue/pi/slow", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"installing"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Hour
+
+	polls := 0
+	_, err := c.Pi().Create(testContext(), "slow", piapi.CreateRequest{Model: 4}, piapi.CreateOptions{
+		Timeout:      20 * time.Millisecond,
+		PollInterval: time.Millisecond,
+		OnProgress:   func(piapi.ProvisioningStatus) { polls++ },
+	})
+	var timeout *piapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Identifier != "slow" || timeout.Last != "installing" {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+	if polls < 2 {
+		t.Fatalf("polls=%d, want PollInterval to be used", polls)
+	}
+}
+
+func TestRaspberryPis_CreateAsync_AccountErrors(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/unpaid", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"Account has an unpaid balance"}`))
+	})
+	mux.HandleFunc("/pi/servers/limited", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"Pi limit reached"}`))
+	})
+	mux.HandleFunc("/pi/servers/denied", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"API key does not have permission to provision Pis"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.Pi().CreateAsync(testContext(), "unpaid", piapi.CreateRequest{Model: 4})
+	var payment *mythicbeasts.ErrPaymentRequired
+	if !errors.As(err, &payment) || payment.Message != "Account has an unpaid balance" {
+		t.Fatalf("err=%v, want ErrPaymentRequired", err)
+	}
+
+	_, err = c.Pi().CreateAsync(testContext(), "limited", piapi.CreateRequest{Model: 4})
+	var quota *piapi.ErrQuotaExceeded
+	if !errors.As(err, &quota) || quota.Message != "Pi limit reached" {
+		t.Fatalf("err=%v, want ErrQuotaExceeded", err)
+	}
+	var apiErr *mythicbeasts.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("err=%v, want wrapped APIError", err)
+	}
+
+	_, err = c.Pi().CreateAsync(testContext(), "denied", piapi.CreateRequest{Model: 4})
+	if errors.As(err, &quota) || errors.As(err, &payment) {
+		t.Fatalf("err=%v, want plain APIError", err)
+	}
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("err=%v, want APIError", err)
+	}
+}
+
+func TestRaspberryPis_Create_ProvisioningError(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/queue/pi/broken")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/queue/pi/broken", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			_, _ = w.Write([]byte(`{"status":"installing"}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"Failed to write image to SD card"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	_, err := c.Pi().Create(testContext(), "broken", piapi.CreateRequest{Model: 4})
+	var provErr *piapi.ProvisioningError
+	if !errors.As(err, &provErr) {
+		t.Fatalf("err=%v, want ProvisioningError", err)
+	}
+	if provErr.Phase != piapi.ProvisioningInstalling || provErr.Reason != "Failed to write image to SD card" {
+		t.Fatalf("err=%+v", provErr)
+	}
+	if len(provErr.Statuses) != 3 || provErr.Statuses[2].State != piapi.ProvisioningFailed {
+		t.Fatalf("statuses=%+v", provErr.Statuses)
+	}
+	if provErr.Elapsed <= 0 {
+		t.Fatalf("elapsed=%v, want positive", provErr.Elapsed)
+	}
+	if !strings.Contains(err.Error(), "while installing") {
+		t.Fatalf("err=%q, want phase in message", err)
+	}
+}
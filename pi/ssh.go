@@ -0,0 +1,273 @@
+package pi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSSHPort is used when a server does not report an SSH port.
+const DefaultSSHPort = 22
+
+// DefaultRebootProbeDelay is the default wait before the first dial
+// in RebootAndWaitForSSH, giving the reboot time to start.
+const DefaultRebootProbeDelay = 5 * time.Second
+
+// sshBannerTimeout bounds the wait for an SSH banner after connecting.
+const sshBannerTimeout = 10 * time.Second
+
+// SSHWaitOptions controls WaitForSSH.
+type SSHWaitOptions struct {
+	// Wait controls the interval between attempts and the overall timeout.
+	Wait WaitOptions
+	// Banner also requires sshd to send its SSH identification banner,
+	// not just accept the TCP connection.
+	Banner bool
+	// Route selects how the Pi is reached.
+	Route SSHRoute
+	// Dial opens the connection. If Dial is nil, a net.Dialer is used.
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// WaitForSSH dials the SSH server of the Pi over opts.Route until it
+// accepts a connection, and with opts.Banner until sshd sends its banner.
+// A Pi reports being live before sshd is listening, so use this
+// before connecting to a new or rebooted Pi.
+// Returns ErrWaitTimeout if SSH is not reachable in time.
+func WaitForSSH(ctx context.Context, server Server, opts SSHWaitOptions) error {
+	return waitForSSH(ctx, server, opts, false)
+}
+
+// waitForSSH dials the SSH server of the Pi until it is reachable.
+// With requireDown it must first be seen unreachable.
+func waitForSSH(ctx context.Context, server Server, opts SSHWaitOptions, requireDown bool) error {
+	host, port, err := server.sshHost(opts.Route)
+	if err != nil {
+		return err
+	}
+	addr := net.JoinHostPort(host, strconv.FormatInt(port, 10))
+
+	dial := opts.dialer()
+
+	want := "reachable over ssh"
+	if requireDown {
+		want = "reachable over ssh after reboot"
+	}
+
+	var (
+		lastErr error
+		down    = !requireDown
+	)
+	err = opts.Wait.poll(ctx, func(ctx context.Context) (bool, error) {
+		lastErr = dialSSH(ctx, dial, addr, opts.Banner)
+		if lastErr != nil {
+			down = true
+		}
+		return down && lastErr == nil, nil
+	})
+	if errors.Is(err, errWaitTimeout) {
+		last := "not seen down"
+		if lastErr != nil {
+			last = lastErr.Error()
+		}
+		return &ErrWaitTimeout{Identifier: server.Identifier, Want: want, Last: last}
+	}
+
+	return err
+}
+
+// dialer returns opts.Dial, or a net.Dialer if it is nil.
+func (opts SSHWaitOptions) dialer() func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if opts.Dial != nil {
+		return opts.Dial
+	}
+	var d net.Dialer
+	return d.DialContext
+}
+
+// dialSSH connects to addr and, if banner is set, reads the
+// SSH identification line.
+func dialSSH(ctx context.Context, dial func(ctx context.Context, network, addr string) (net.Conn, error), addr string, banner bool) error {
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if !banner {
+		return nil
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(sshBannerTimeout)); err != nil {
+		return err
+	}
+	return readSSHBanner(bufio.NewReader(conn))
+}
+
+// RebootAndWaitForSSH reboots the Pi, waits for delay to give the
+// reboot time to start, then dials SSH until the Pi has been seen
+// unreachable and is reachable again. Requiring the Pi to be seen down
+// means it is not reported as back before the reboot has happened, so
+// opts.Wait.Interval must be short enough to see it down.
+// If delay <= 0, DefaultRebootProbeDelay is used.
+// Returns ErrWaitTimeout if the Pi is not seen to go down and come back in time.
+func (s *Service) RebootAndWaitForSSH(ctx context.Context, identifier string, delay time.Duration, opts SSHWaitOptions) (RebootResponse, error) {
+	server, err := s.Get(ctx, identifier)
+	if err != nil {
+		return RebootResponse{}, err
+	}
+
+	resp, err := s.Reboot(ctx, identifier)
+	if err != nil {
+		return RebootResponse{}, err
+	}
+
+	if delay <= 0 {
+		delay = DefaultRebootProbeDelay
+	}
+	timer := time.NewTimer(delay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return RebootResponse{}, ctx.Err()
+	case <-timer.C:
+	}
+
+	if err := waitForSSH(ctx, server, opts, true); err != nil {
+		return RebootResponse{}, err
+	}
+
+	return resp, nil
+}
+
+// ErrSSHUnavailable is returned when a server has no hostname
+// or IP address to connect to.
+var ErrSSHUnavailable = errors.New("ssh is not available")
+
+// DefaultSSHUser is the user Pis are provisioned with.
+const DefaultSSHUser = "root"
+
+// SSHRoute selects how a Pi is reached over SSH.
+type SSHRoute string
+
+const (
+	// SSHRouteAuto uses SSHRouteProxy for a Pi with a hostname and
+	// an SSH port, and SSHRouteDirect otherwise.
+	SSHRouteAuto SSHRoute = ""
+	// SSHRouteProxy connects over IPv4 through the Pi's SSH proxy host,
+	// ssh.<fqdn>, on the Pi's SSHPort.
+	SSHRouteProxy SSHRoute = "proxy"
+	// SSHRouteDirect connects over IPv6 to the Pi's own FQDN, or its IP
+	// address, on DefaultSSHPort.
+	SSHRouteDirect SSHRoute = "direct"
+)
+
+// SSHProxyHost returns the host of the Pi's IPv4 SSH proxy,
+// ssh.<fqdn>, which forwards the Pi's SSHPort to it.
+// Returns "" if the Pi has no hostname.
+func (s Server) SSHProxyHost() string {
+	fqdn := s.FQDN()
+	if fqdn == "" {
+		return ""
+	}
+	return "ssh." + fqdn
+}
+
+// sshHost returns the host and port to connect to over route.
+func (s Server) sshHost(route SSHRoute) (string, int64, error) {
+	if route == SSHRouteAuto {
+		route = SSHRouteDirect
+		if s.SSHPort != 0 && s.FQDN() != "" {
+			route = SSHRouteProxy
+		}
+	}
+
+	switch route {
+	case SSHRouteProxy:
+		host := s.SSHProxyHost()
+		if host == "" || s.SSHPort == 0 {
+			return "", 0, ErrSSHUnavailable
+		}
+		return host, s.SSHPort, nil
+	case SSHRouteDirect:
+		host := s.FQDN()
+		if host == "" {
+			host = s.IP
+		}
+		if host == "" {
+			return "", 0, ErrSSHUnavailable
+		}
+		return host, DefaultSSHPort, nil
+	default:
+		return "", 0, fmt.Errorf("invalid ssh route %q", route)
+	}
+}
+
+// SSHCommand returns an ssh command line that connects to the Pi
+// over route. If user is empty DefaultSSHUser is used.
+// Returns ErrSSHUnavailable if the Pi cannot be reached over route.
+func (s Server) SSHCommand(route SSHRoute, user string) (string, error) {
+	host, port, err := s.sshHost(route)
+	if err != nil {
+		return "", err
+	}
+	if user == "" {
+		user = DefaultSSHUser
+	}
+
+	if port == DefaultSSHPort {
+		return fmt.Sprintf("ssh %s@%s", user, host), nil
+	}
+	return fmt.Sprintf("ssh -p %d %s@%s", port, user, host), nil
+}
+
+// SSHConfig returns an ssh_config Host entry named alias that
+// connects to the Pi over route. If alias is empty the identifier is
+// used, and if user is empty DefaultSSHUser is used.
+// Returns ErrSSHUnavailable if the Pi cannot be reached over route.
+func (s Server) SSHConfig(route SSHRoute, alias, user string) (string, error) {
+	host, port, err := s.sshHost(route)
+	if err != nil {
+		return "", err
+	}
+	if alias == "" {
+		alias = s.Identifier
+	}
+	if alias == "" {
+		alias = host
+	}
+	if user == "" {
+		user = DefaultSSHUser
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Host %s\n", alias)
+	fmt.Fprintf(&b, "    HostName %s\n", host)
+	fmt.Fprintf(&b, "    Port %d\n", port)
+	fmt.Fprintf(&b, "    User %s\n", user)
+	return b.String(), nil
+}
+
+// KnownHostsAddr returns the Pi's address over route as written in
+// known_hosts: the bare host on port 22, otherwise "[host]:port".
+// Returns ErrSSHUnavailable if the Pi cannot be reached over route.
+func (s Server) KnownHostsAddr(route SSHRoute) (string, error) {
+	host, port, err := s.sshHost(route)
+	if err != nil {
+		return "", err
+	}
+	return knownHostsAddr(host, port), nil
+}
+
+// knownHostsAddr formats host and port as written in known_hosts.
+func knownHostsAddr(host string, port int64) string {
+	if port == DefaultSSHPort {
+		return host
+	}
+	return fmt.Sprintf("[%s]:%d", host, port)
+}
@@ -0,0 +1,93 @@
+package pi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/sshkeys"
+)
+
+// SSHKeys is a list of OpenSSH public keys.
+type SSHKeys = sshkeys.Keys
+
+// ParseSSHKeys splits a newline-separated list of public keys,
+// skipping blank lines and comments, and validates every key.
+func ParseSSHKeys(s string) (SSHKeys, error) {
+	return sshkeys.Parse(s)
+}
+
+// SetSSHKeys validates the keys and sets them on the request
+// with duplicates removed.
+func (r *UpdateSSHKeyRequest) SetSSHKeys(keys SSHKeys) error {
+	if err := keys.Validate(); err != nil {
+		return err
+	}
+	r.SSHKey = keys.Dedupe().String()
+	return nil
+}
+
+// SSHKeyOptions controls SetSSHKeys.
+type SSHKeyOptions struct {
+	// Append keeps the keys already installed on the Pi and adds
+	// the new keys to them, instead of replacing them.
+	Append bool
+}
+
+// SetSSHKeys validates keys and installs them in the Pi's
+// /root/.ssh/authorized_keys, replacing its contents unless
+// opts.Append is set. Duplicate keys are removed.
+// It returns the keys now installed.
+// Returns ErrEmptyIdentifier or ErrInvalidIdentifier if the identifier
+// is blank or malformed, see ValidateIdentifier.
+func (s *Service) SetSSHKeys(ctx context.Context, identifier string, keys SSHKeys, opts SSHKeyOptions) (SSHKeys, error) {
+	if err := ValidateIdentifier(identifier); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("ssh key is required")
+	}
+	if err := keys.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.Append {
+		installed, err := s.GetSSHKeys(ctx, identifier)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(installed, keys...)
+	}
+
+	req := UpdateSSHKeyRequest{SSHKey: keys.Dedupe().String()}
+	resp, err := s.UpdateSSHKey(ctx, identifier, req)
+	if err != nil {
+		return nil, err
+	}
+
+	installed, _ := sshkeys.Parse(resp.SSHKey)
+	return installed, nil
+}
+
+// GetSSHKeys retrieves the keys in the Pi's /root/.ssh/authorized_keys.
+// Blank lines and comments are skipped. Keys this package does not
+// recognise are still returned, so the result can be compared with
+// the expected keys to detect drift.
+// Returns ErrEmptyIdentifier or ErrInvalidIdentifier if the identifier
+// is blank or malformed, see ValidateIdentifier.
+func (s *Service) GetSSHKeys(ctx context.Context, identifier string) (SSHKeys, error) {
+	if err := ValidateIdentifier(identifier); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/pi/servers/%s/ssh-key", identifier)
+
+	var result UpdateSSHKeyResponse
+	if _, _, err := s.GetJSON(ctx, url, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	keys, _ := sshkeys.Parse(result.SSHKey)
+	return keys, nil
+}
@@ -0,0 +1,91 @@
+package pi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+const (
+	testKeyA = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC5cSqQNVmTIWz9901r8HB+DiwmnFYRWYXChyqigkzAA"
+	testKeyB = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f"
+)
+
+func sshKeyMux(t *testing.T, installed *string) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1/ssh-key", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			var req piapi.UpdateSSHKeyRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			*installed = req.SSHKey
+		case http.MethodGet:
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+		_ = json.NewEncoder(w).Encode(piapi.UpdateSSHKeyResponse{SSHKey: *installed})
+	})
+	return mux
+}
+
+func TestRaspberryPis_SetSSHKeys_Append(t *testing.T) {
+	t.Parallel()
+	installed := testKeyA + " alice\n"
+	c, srv := newTestClient(t, sshKeyMux(t, &installed))
+	defer srv.Close()
+
+	keys, err := c.Pi().SetSSHKeys(testContext(), "pi1", piapi.SSHKeys{testKeyA, testKeyB}, piapi.SSHKeyOptions{Append: true})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if installed != testKeyA+" alice\n"+testKeyB {
+		t.Fatalf("installed=%q", installed)
+	}
+	if len(keys) != 2 || keys[1] != testKeyB {
+		t.Fatalf("keys=%v", keys)
+	}
+}
+
+func TestRaspberryPis_SetSSHKeys_Replace(t *testing.T) {
+	t.Parallel()
+	installed := testKeyA
+	c, srv := newTestClient(t, sshKeyMux(t, &installed))
+	defer srv.Close()
+
+	if _, err := c.Pi().SetSSHKeys(testContext(), "pi1", piapi.SSHKeys{testKeyB}, piapi.SSHKeyOptions{}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if installed != testKeyB {
+		t.Fatalf("installed=%q", installed)
+	}
+
+	if _, err := c.Pi().SetSSHKeys(testContext(), "pi1", piapi.SSHKeys{"ssh-rsa AAAAB..."}, piapi.SSHKeyOptions{}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if installed != testKeyB {
+		t.Fatalf("invalid key was sent: %q", installed)
+	}
+}
+
+func TestRaspberryPis_GetSSHKeys(t *testing.T) {
+	t.Parallel()
+	installed := "# managed by ansible\n" + testKeyA + " alice\n\nssh-unknown AAAA legacy\n"
+	c, srv := newTestClient(t, sshKeyMux(t, &installed))
+	defer srv.Close()
+
+	keys, err := c.Pi().GetSSHKeys(testContext(), "pi1")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != testKeyA+" alice" || keys[1] != "ssh-unknown AAAA legacy" {
+		t.Fatalf("keys=%q", keys)
+	}
+
+	if _, err := c.Pi().GetSSHKeys(testContext(), ""); !errors.Is(err, piapi.ErrEmptyIdentifier) {
+		t.Fatalf("err=%v, want ErrEmptyIdentifier", err)
+	}
+}
@@ -0,0 +1,200 @@
+package pi_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func sshListener(t *testing.T, banner string) (*net.TCPAddr, func()) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte(banner))
+			_ = conn.Close()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr), func() { _ = ln.Close() }
+}
+
+// dialTo returns a dial function that connects to addr instead,
+// recording the addresses asked for.
+func dialTo(addr *net.TCPAddr, dialed *[]string) func(ctx context.Context, network, address string) (net.Conn, error) {
+	var mu sync.Mutex
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		mu.Lock()
+		*dialed = append(*dialed, address)
+		mu.Unlock()
+		var d net.Dialer
+		return d.DialContext(ctx, network, addr.String())
+	}
+}
+
+func TestWaitForSSH(t *testing.T) {
+	t.Parallel()
+	addr, stop := sshListener(t, "SSH-2.0-OpenSSH_9.2p1\r\n")
+	defer stop()
+
+	server := piapi.Server{Identifier: "pi1", IP: "2a00:1098:8:1::1", SSHPort: 5001}
+	for route, want := range map[piapi.SSHRoute]string{
+		piapi.SSHRouteProxy:  "ssh.pi1.hostedpi.com:5001",
+		piapi.SSHRouteDirect: "pi1.hostedpi.com:22",
+	} {
+		var dialed []string
+		opts := piapi.SSHWaitOptions{
+			Banner: true,
+			Route:  route,
+			Dial:   dialTo(addr, &dialed),
+			Wait:   piapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+		}
+		if err := piapi.WaitForSSH(testContext(), server, opts); err != nil {
+			t.Fatalf("%s: err: %v", route, err)
+		}
+		if len(dialed) != 1 || dialed[0] != want {
+			t.Fatalf("%s: dialed=%v, want %s", route, dialed, want)
+		}
+	}
+}
+
+func TestWaitForSSH_BadBanner(t *testing.T) {
+	t.Parallel()
+	addr, stop := sshListener(t, "HTTP/1.1 400 Bad Request\r\n")
+	defer stop()
+
+	var dialed []string
+	server := piapi.Server{Identifier: "pi1", SSHPort: 5001}
+	opts := piapi.SSHWaitOptions{Banner: true, Dial: dialTo(addr, &dialed), Wait: piapi.WaitOptions{Interval: time.Millisecond, Timeout: 5 * time.Millisecond}}
+	var timeout *piapi.ErrWaitTimeout
+	if err := piapi.WaitForSSH(testContext(), server, opts); !errors.As(err, &timeout) {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+
+	opts.Banner = false
+	if err := piapi.WaitForSSH(testContext(), server, opts); err != nil {
+		t.Fatalf("tcp only err: %v", err)
+	}
+}
+
+func TestRaspberryPis_RebootAndWaitForSSH(t *testing.T) {
+	t.Parallel()
+	addr, stop := sshListener(t, "SSH-2.0-OpenSSH_9.2p1\r\n")
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ip":"` + addr.IP.String() + `","ssh_port":` + strconv.Itoa(addr.Port) + `}`))
+	})
+	mux.HandleFunc("/pi/servers/pi1/reboot", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	var dialed []string
+	dial := dialTo(addr, &dialed)
+	opts := piapi.SSHWaitOptions{
+		Banner: true,
+		Route:  piapi.SSHRouteDirect,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			if len(dialed) == 0 {
+				dialed = append(dialed, address)
+				return nil, errors.New("connection refused")
+			}
+			return dial(ctx, network, address)
+		},
+		Wait: piapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+	}
+	if _, err := c.Pi().RebootAndWaitForSSH(testContext(), "pi1", time.Millisecond, opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(dialed) != 2 {
+		t.Fatalf("dialed=%v, want a failed dial then a successful one", dialed)
+	}
+}
+
+func TestRaspberryPis_RebootAndWaitForSSH_RequiresDown(t *testing.T) {
+	t.Parallel()
+	addr, stop := sshListener(t, "SSH-2.0-OpenSSH_9.2p1\r\n")
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ip":"` + addr.IP.String() + `","ssh_port":` + strconv.Itoa(addr.Port) + `}`))
+	})
+	mux.HandleFunc("/pi/servers/pi1/reboot", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	var dialed []string
+	opts := piapi.SSHWaitOptions{Banner: true, Route: piapi.SSHRouteDirect, Dial: dialTo(addr, &dialed), Wait: piapi.WaitOptions{Interval: time.Millisecond, Timeout: 20 * time.Millisecond}}
+	_, err := c.Pi().RebootAndWaitForSSH(testContext(), "pi1", time.Millisecond, opts)
+	var timeout *piapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Last != "not seen down" {
+		t.Fatalf("err=%v, want ErrWaitTimeout not seen down", err)
+	}
+}
+
+func TestServer_SSHHelpers(t *testing.T) {
+	t.Parallel()
+	server := piapi.Server{Identifier: "web1", IP: "2a00:1098:8:1::1", SSHPort: 5123}
+
+	cmd, err := server.SSHCommand(piapi.SSHRouteAuto, "")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if cmd != "ssh -p 5123 root@ssh.web1.hostedpi.com" {
+		t.Fatalf("cmd=%q", cmd)
+	}
+	if cmd, _ := server.SSHCommand(piapi.SSHRouteDirect, ""); cmd != "ssh root@web1.hostedpi.com" {
+		t.Fatalf("direct cmd=%q", cmd)
+	}
+
+	config, err := server.SSHConfig(piapi.SSHRouteProxy, "", "pi")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := "Host web1\n    HostName ssh.web1.hostedpi.com\n    Port 5123\n    User pi\n"
+	if config != want {
+		t.Fatalf("config=%q, want %q", config, want)
+	}
+
+	addr, err := server.KnownHostsAddr(piapi.SSHRouteAuto)
+	if err != nil || addr != "[ssh.web1.hostedpi.com]:5123" {
+		t.Fatalf("addr=%q err=%v", addr, err)
+	}
+	if addr, _ := server.KnownHostsAddr(piapi.SSHRouteDirect); addr != "web1.hostedpi.com" {
+		t.Fatalf("direct addr=%q", addr)
+	}
+
+	ipOnly := piapi.Server{IP: "2a00:1098:8:1::1", SSHPort: 5123}
+	if addr, _ := ipOnly.KnownHostsAddr(piapi.SSHRouteAuto); addr != "2a00:1098:8:1::1" {
+		t.Fatalf("addr=%q", addr)
+	}
+	if cmd, _ := ipOnly.SSHCommand(piapi.SSHRouteAuto, "admin"); cmd != "ssh admin@2a00:1098:8:1::1" {
+		t.Fatalf("cmd=%q", cmd)
+	}
+	if _, err := ipOnly.SSHCommand(piapi.SSHRouteProxy, ""); !errors.Is(err, piapi.ErrSSHUnavailable) {
+		t.Fatalf("err=%v, want ErrSSHUnavailable", err)
+	}
+
+	if _, err := (piapi.Server{}).SSHCommand(piapi.SSHRouteAuto, ""); !errors.Is(err, piapi.ErrSSHUnavailable) {
+		t.Fatalf("err=%v, want ErrSSHUnavailable", err)
+	}
+}
@@ -0,0 +1,75 @@
+package pi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiskSizeExtent is the step in GB that Pi disk sizes must be a multiple of.
+const DiskSizeExtent = 10
+
+// ValidateCreateRequest checks the request against the models and
+// operating system images offered by the API, returning an error for
+// every problem found joined with errors.Join.
+// Fields left unset are not checked, as the API chooses a default for them.
+// It is much quicker than waiting for the provisioning request to fail.
+func (s *Service) ValidateCreateRequest(ctx context.Context, req CreateRequest) error {
+	var errs []error
+
+	if req.DiskSize < 0 || req.DiskSize%DiskSizeExtent != 0 {
+		errs = append(errs, fmt.Errorf("disk size %d must be a positive multiple of %d", req.DiskSize, DiskSizeExtent))
+	}
+
+	if req.Model != 0 || req.Memory != 0 || req.CPUSpeed != 0 {
+		models, err := s.ListModels(ctx)
+		if err != nil {
+			return err
+		}
+		if err := matchModel(models, req); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if req.OSImage != "" {
+		if req.Model == 0 {
+			errs = append(errs, errors.New("os image requires a model"))
+		} else {
+			images, err := s.GetOperatingSystems(ctx, req.Model)
+			if err != nil {
+				return err
+			}
+			if _, ok := images[req.OSImage]; !ok {
+				ids := make([]string, 0, len(images))
+				for id := range images {
+					ids = append(ids, id)
+				}
+				sort.Strings(ids)
+				errs = append(errs, fmt.Errorf("os image %q is not available for model %d, available: %s", req.OSImage, req.Model, strings.Join(ids, ", ")))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// matchModel checks that a model matches the model, memory and
+// CPU speed of the request, ignoring fields that are unset.
+func matchModel(models []Model, req CreateRequest) error {
+	found := false
+	for _, m := range models {
+		if req.Model != 0 && m.Model != req.Model {
+			continue
+		}
+		found = true
+		if (req.Memory == 0 || m.Memory == req.Memory) && (req.CPUSpeed == 0 || m.CPUSpeed == req.CPUSpeed) {
+			return nil
+		}
+	}
+	if !found {
+		return fmt.Errorf("model %d is not available", req.Model)
+	}
+	return fmt.Errorf("model %d is not available with memory %d and cpu speed %d", req.Model, req.Memory, req.CPUSpeed)
+}
@@ -0,0 +1,53 @@
+package pi_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func validateMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/models", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"models":[{"model":4,"memory":4096,"cpu_speed":1500},{"model":4,"memory":8192,"cpu_speed":1500}]}`))
+	})
+	mux.HandleFunc("/pi/images/4", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"rpi-bookworm-arm64":"Raspberry Pi OS Bookworm (64-bit)"}`))
+	})
+	return mux
+}
+
+func TestRaspberryPis_ValidateCreateRequest(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, validateMux())
+	defer srv.Close()
+
+	valid := piapi.CreateRequest{Model: 4, Memory: 8192, DiskSize: 20, OSImage: "rpi-bookworm-arm64"}
+	if err := c.Pi().ValidateCreateRequest(testContext(), valid); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	invalid := piapi.CreateRequest{Model: 4, Memory: 2048, DiskSize: 15, OSImage: "rpi-buster-armhf"}
+	err := c.Pi().ValidateCreateRequest(testContext(), invalid)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"disk size 15", "memory 2048", `os image "rpi-buster-armhf"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("err=%q, want %q", err, want)
+		}
+	}
+}
+
+func TestRaspberryPis_ValidateCreateRequest_UnknownModel(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, validateMux())
+	defer srv.Close()
+
+	err := c.Pi().ValidateCreateRequest(testContext(), piapi.CreateRequest{Model: 3})
+	if err == nil || !strings.Contains(err.Error(), "model 3 is not available") {
+		t.Fatalf("err=%v, want unknown model", err)
+	}
+}
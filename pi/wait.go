@@ -0,0 +1,106 @@
+package pi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/wait"
+)
+
+const (
+	// DefaultWaitInterval is the default wait between polls in wait helpers.
+	DefaultWaitInterval = 10 * time.Second
+	// DefaultWaitTimeout is the default time limit of wait helpers.
+	DefaultWaitTimeout = 5 * time.Minute
+)
+
+// WaitOptions controls polling in the wait helpers.
+type WaitOptions struct {
+	// Interval is the wait between polls.
+	// If Interval <= 0, DefaultWaitInterval is used.
+	Interval time.Duration
+	// Timeout bounds the total wait.
+	// If Timeout <= 0, DefaultWaitTimeout is used.
+	Timeout time.Duration
+}
+
+// errWaitTimeout is returned by poll when the timeout is reached.
+var errWaitTimeout = wait.ErrTimeout
+
+// poll calls check until it reports done, returns an error,
+// or the timeout or context ends.
+func (o WaitOptions) poll(ctx context.Context, check func(ctx context.Context) (bool, error)) error {
+	return wait.Poll(ctx, o.interval(), o.timeout(), check)
+}
+
+// interval returns Interval, or DefaultWaitInterval if it is not set.
+func (o WaitOptions) interval() time.Duration {
+	if o.Interval <= 0 {
+		return DefaultWaitInterval
+	}
+	return o.Interval
+}
+
+// timeout returns Timeout, or DefaultWaitTimeout if it is not set.
+func (o WaitOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return DefaultWaitTimeout
+	}
+	return o.Timeout
+}
+
+// WaitForBoot polls the Pi until it is live: provisioned, not
+// booting and not powered off. A Pi whose power state the API does
+// not report is taken to be on.
+// Returns ErrWaitTimeout if it has not booted in time.
+func (s *Service) WaitForBoot(ctx context.Context, identifier string, opts WaitOptions) (Server, error) {
+	return s.waitFor(ctx, identifier, "booted", opts, func(server Server) bool {
+		return server.State() == StateLive
+	})
+}
+
+// DeleteAndWait removes the Pi and polls until it is no longer found,
+// so the identifier can be reused straight away.
+// Returns ErrWaitTimeout if the Pi is still found when the wait times out.
+func (s *Service) DeleteAndWait(ctx context.Context, identifier string, opts WaitOptions) error {
+	if err := s.Delete(ctx, identifier); err != nil {
+		return err
+	}
+
+	last := "found"
+	err := opts.poll(ctx, func(ctx context.Context) (bool, error) {
+		server, err := s.Get(ctx, identifier)
+		if isNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if server.Status != "" {
+			last = server.Status
+		}
+		return false, nil
+	})
+	if errors.Is(err, errWaitTimeout) {
+		return &ErrWaitTimeout{Identifier: identifier, Want: "deleted", Last: last}
+	}
+
+	return err
+}
+
+// waitFor polls the Pi until match reports true.
+// want describes the awaited state in timeout errors.
+func (s *Service) waitFor(ctx context.Context, identifier string, want string, opts WaitOptions, match func(Server) bool) (Server, error) {
+	server, err := wait.For(ctx, opts.interval(), opts.timeout(), func(ctx context.Context) (Server, error) {
+		return s.Get(ctx, identifier)
+	}, match)
+	if errors.Is(err, errWaitTimeout) {
+		return server, &ErrWaitTimeout{Identifier: identifier, Want: want, Last: server.Status}
+	}
+	if err != nil {
+		return Server{}, err
+	}
+
+	return server, nil
+}
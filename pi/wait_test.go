@@ -0,0 +1,60 @@
+package pi_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestRaspberryPis_DeleteAndWait(t *testing.T) {
+	t.Parallel()
+	deleted := false
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			gets++
+			if !deleted || gets < 2 {
+				_, _ = w.Write([]byte(`{"status":"deleting"}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	if err := c.Pi().DeleteAndWait(testContext(), "pi1", piapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if gets != 2 {
+		t.Fatalf("gets=%d, want 2", gets)
+	}
+}
+
+func TestRaspberryPis_DeleteAndWait_Timeout(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"deleting"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	err := c.Pi().DeleteAndWait(testContext(), "pi1", piapi.WaitOptions{Interval: time.Millisecond, Timeout: 5 * time.Millisecond})
+	var timeout *piapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Want != "deleted" || timeout.Last != "deleting" {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+}
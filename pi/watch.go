@@ -0,0 +1,88 @@
+package pi
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// ServerState is the state of a Pi as seen by Watch.
+type ServerState string
+
+const (
+	StateProvisioning ServerState = "provisioning"
+	StateBooting      ServerState = "booting"
+	StateLive         ServerState = "live"
+	StatePoweredOff   ServerState = "powered off"
+	StateUnreachable  ServerState = "unreachable"
+)
+
+// State reports the state of the Pi from its status, booting flag and
+// PowerState. A Pi whose power state is unknown is reported as live.
+// It never reports StateUnreachable.
+func (s Server) State() ServerState {
+	if s.Status != "" {
+		switch parseProvisioningState(s.Status) {
+		case ProvisioningQueued, ProvisioningInstalling:
+			return StateProvisioning
+		}
+	}
+	switch {
+	case bool(s.IsBooting):
+		return StateBooting
+	case s.PowerState() == PowerStateOff:
+		return StatePoweredOff
+	default:
+		return StateLive
+	}
+}
+
+// WatchEvent is a change in the state of a watched Pi.
+type WatchEvent struct {
+	State ServerState
+	// Server is the Pi as last retrieved. It is empty while unreachable.
+	Server Server
+	// Err is why the Pi could not be retrieved, when State is StateUnreachable.
+	Err error
+}
+
+// Watch polls the Pi every interval and yields an event whenever its
+// State changes, starting with its current state.
+// A failed poll is reported as StateUnreachable with the error, and
+// repeated failures are not reported again until the Pi is reachable.
+// If interval <= 0, DefaultWaitInterval is used.
+// The iterator ends when ctx is done or the loop breaks.
+func (s *Service) Watch(ctx context.Context, identifier string, interval time.Duration) iter.Seq[WatchEvent] {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	return func(yield func(WatchEvent) bool) {
+		var last ServerState
+		for {
+			server, err := s.Get(ctx, identifier)
+			if ctx.Err() != nil {
+				return
+			}
+
+			event := WatchEvent{State: StateUnreachable, Err: err}
+			if err == nil {
+				event = WatchEvent{State: server.State(), Server: server}
+			}
+			if event.State != last {
+				last = event.State
+				if !yield(event) {
+					return
+				}
+			}
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package pi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	piapi "github.com/paultibbetts/mythicbeasts-client-go/pi"
+)
+
+func TestRaspberryPis_Watch(t *testing.T) {
+	t.Parallel()
+	responses := []string{
+		`{"status":"installing"}`,
+		`{"status":"live","power":true,"is_booting":true}`,
+		`{"status":"live","power":true,"is_booting":true}`,
+		``,
+		``,
+		`{"status":"live","power":true}`,
+		`{"status":"live","power":false}`,
+	}
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pi/servers/pi1", func(w http.ResponseWriter, r *http.Request) {
+		body := responses[min(gets, len(responses)-1)]
+		gets++
+		if body == "" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(testContext(), 5*time.Second)
+	defer cancel()
+
+	var seen []piapi.ServerState
+	for event := range c.Pi().Watch(ctx, "pi1", time.Millisecond) {
+		if event.State == piapi.StateUnreachable && event.Err == nil {
+			t.Fatalf("event=%+v, want error", event)
+		}
+		seen = append(seen, event.State)
+		if event.State == piapi.StatePoweredOff {
+			break
+		}
+	}
+
+	if fmt.Sprint(seen) != "[provisioning booting unreachable live powered off]" {
+		t.Fatalf("seen=%v", seen)
+	}
+}
+
+func TestServer_State_PowerNotReported(t *testing.T) {
+	t.Parallel()
+	for body, want := range map[string]piapi.ServerState{
+		`{"status":"live"}`:                    piapi.StateLive,
+		`{"status":"live","power":false}`:      piapi.StatePoweredOff,
+		`{"status":"live","is_booting":true}`:  piapi.StateBooting,
+		`{"status":"installing","power":true}`: piapi.StateProvisioning,
+	} {
+		var server piapi.Server
+		if err := json.Unmarshal([]byte(body), &server); err != nil {
+			t.Fatalf("%s: err: %v", body, err)
+		}
+		if got := server.State(); got != want {
+			t.Fatalf("%s: state=%q, want %q", body, got, want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/netip"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
@@ -89,6 +90,23 @@ func (s *Service) ListEndpoints(ctx context.Context, domain string) ([]Endpoint,
 	return result.Endpoints, nil
 }
 
+// ListDomains retrieves the domains that have endpoints on the proxy,
+// sorted and without duplicates.
+func (s *Service) ListDomains(ctx context.Context) ([]string, error) {
+	endpoints, err := s.ListEndpoints(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []string
+	for _, endpoint := range endpoints {
+		domains = append(domains, endpoint.Domain)
+	}
+	slices.Sort(domains)
+
+	return slices.Compact(domains), nil
+}
+
 // GetEndpoints retrieves endpoints for a specific hostname (and optionally address/site).
 // A 404 response is treated as "not found" and returns found=false with no error.
 func (s *Service) GetEndpoints(ctx context.Context, domain, hostname, address, site string) ([]Endpoint, bool, error) {
@@ -111,11 +129,11 @@ func (s *Service) GetEndpoints(ctx context.Context, domain, hostname, address, s
 		return nil, false, nil
 	}
 	if res.StatusCode != http.StatusOK {
-		return nil, false, fmt.Errorf("unexpected status %d: %s", res.StatusCode, string(body))
+		return nil, false, s.NewAPIError(res, body)
 	}
 
 	var result endpointsResponse
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := s.Decode(endpoint, body, &result); err != nil {
 		return nil, false, err
 	}
 
@@ -192,15 +210,59 @@ func (s *Service) CreateOrUpdateEndpoints(ctx context.Context, domain, hostname,
 	return result.Endpoints, nil
 }
 
+// ReplaceDomainEndpoints replaces every endpoint of the domain with
+// endpoints in a single request, so all hostnames move to their new
+// addresses at once. Each endpoint must name its hostname; hostnames
+// of the domain that have no endpoints are removed.
+func (s *Service) ReplaceDomainEndpoints(ctx context.Context, domain string, endpoints []EndpointRequest) ([]Endpoint, error) {
+	if strings.TrimSpace(domain) == "" {
+		return nil, errors.New("domain is required")
+	}
+
+	requests := make([]EndpointRequest, len(endpoints))
+	for i, endpoint := range endpoints {
+		if strings.TrimSpace(endpoint.Hostname) == "" {
+			return nil, fmt.Errorf("endpoint %d: hostname is required", i)
+		}
+		normalized, err := normalizeEndpointRequests(domain, endpoint.Hostname, "", "", []EndpointRequest{endpoint})
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %d: %w", i, err)
+		}
+		requests[i] = normalized[0]
+	}
+
+	endpoint := "/" + path.Join("endpoints", domain)
+
+	var result endpointsResponse
+	if _, _, err := s.DoJSON(ctx, http.MethodPut, endpoint, endpointsRequest{Endpoints: requests}, &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return result.Endpoints, nil
+}
+
 // DeleteEndpoints deletes endpoints matching the provided path.
+// A 404 response counts as success, matching the other services,
+// so teardown can be repeated. See DeleteEndpointsIfExists.
 func (s *Service) DeleteEndpoints(ctx context.Context, domain, hostname, address, site string) error {
+	_, err := s.DeleteEndpointsIfExists(ctx, domain, hostname, address, site)
+	return err
+}
+
+// DeleteEndpointsIfExists deletes endpoints matching the provided path.
+// A 404 response is treated as "not found" and returns found=false with no error.
+func (s *Service) DeleteEndpointsIfExists(ctx context.Context, domain, hostname, address, site string) (bool, error) {
 	endpoint, err := endpointPath(domain, hostname, address, site)
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	_, _, err = s.DoJSON(ctx, http.MethodDelete, endpoint, nil, nil, http.StatusOK)
-	return err
+	res, _, err := s.DoJSON(ctx, http.MethodDelete, endpoint, nil, nil, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return false, err
+	}
+
+	return res.StatusCode != http.StatusNotFound, nil
 }
 
 func endpointPath(domain, hostname, address, site string) (string, error) {
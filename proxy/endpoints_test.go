@@ -134,6 +134,31 @@ func TestAddEndpointsForHost_UnexpectedStatus(t *testing.T) {
 	}
 }
 
+func TestListDomains_OK(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("method=%s, want GET", r.Method)
+		}
+		_, _ = w.Write([]byte(`{"endpoints":[
+			{"domain":"example.org","hostname":"www","address":"2a00:1098:0:82:1000:3b:1:1","site":"all"},
+			{"domain":"example.com","hostname":"www","address":"2a00:1098:0:82:1000:3b:1:1","site":"all"},
+			{"domain":"example.com","hostname":"@","address":"2a00:1098:0:82:1000:3b:1:2","site":"all"}]}`))
+	})
+
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	domains, err := c.Proxy().ListDomains(testContext())
+	if err != nil {
+		t.Fatalf("ListDomains: %v", err)
+	}
+	if len(domains) != 2 || domains[0] != "example.com" || domains[1] != "example.org" {
+		t.Fatalf("domains=%v, want [example.com example.org]", domains)
+	}
+}
+
 func TestGetEndpoints_OK(t *testing.T) {
 	t.Parallel()
 	endpoint := proxyapi.Endpoint{
@@ -350,6 +375,45 @@ func TestCreateOrUpdateEndpoints_UnexpectedStatus(t *testing.T) {
 	}
 }
 
+func TestReplaceDomainEndpoints_OK(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/endpoints/example.com", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("method=%s, want PUT", r.Method)
+		}
+		var req struct {
+			Endpoints []proxyapi.EndpointRequest `json:"endpoints"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode req: %v", err)
+		}
+		if len(req.Endpoints) != 2 || req.Endpoints[0].Domain != "example.com" || req.Endpoints[1].Hostname != "@" {
+			t.Fatalf("endpoints=%+v", req.Endpoints)
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]proxyapi.EndpointRequest{"endpoints": req.Endpoints})
+	})
+
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	addr := proxyapi.IPv6Addr{Addr: mustParseAddr(t, "2a00:1098:0:82:1000:3b:1:2")}
+	got, err := c.Proxy().ReplaceDomainEndpoints(testContext(), "example.com", []proxyapi.EndpointRequest{
+		{Hostname: "www", Address: addr, Site: "all"},
+		{Hostname: "@", Address: addr, Site: "all"},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceDomainEndpoints: %v", err)
+	}
+	if len(got) != 2 || got[0].Address.Addr != addr.Addr {
+		t.Fatalf("endpoints=%+v", got)
+	}
+
+	if _, err := c.Proxy().ReplaceDomainEndpoints(testContext(), "example.com", []proxyapi.EndpointRequest{{Address: addr}}); err == nil {
+		t.Fatalf("expected error for missing hostname")
+	}
+}
+
 func TestDeleteEndpoints_OK(t *testing.T) {
 	t.Parallel()
 	mux := http.NewServeMux()
@@ -366,6 +430,40 @@ func TestDeleteEndpoints_OK(t *testing.T) {
 	if err := c.Proxy().DeleteEndpoints(testContext(), "example.com", "www", "2a00:1098:0:82:1000:3b:1:1", "all"); err != nil {
 		t.Fatalf("DeleteEndpoints: %v", err)
 	}
+
+	found, err := c.Proxy().DeleteEndpointsIfExists(testContext(), "example.com", "www", "2a00:1098:0:82:1000:3b:1:1", "all")
+	if err != nil {
+		t.Fatalf("DeleteEndpointsIfExists: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected found=true")
+	}
+}
+
+func TestDeleteEndpoints_NotFound(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/endpoints/example.com/www", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("method=%s, want DELETE", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	if err := c.Proxy().DeleteEndpoints(testContext(), "example.com", "www", "", ""); err != nil {
+		t.Fatalf("DeleteEndpoints: %v", err)
+	}
+
+	found, err := c.Proxy().DeleteEndpointsIfExists(testContext(), "example.com", "www", "", "")
+	if err != nil {
+		t.Fatalf("DeleteEndpointsIfExists: %v", err)
+	}
+	if found {
+		t.Fatalf("expected found=false")
+	}
 }
 
 func TestDeleteEndpoints_UnexpectedStatus(t *testing.T) {
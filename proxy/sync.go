@@ -0,0 +1,116 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultSite is the site the API uses for endpoints that do not name one.
+const DefaultSite = "all"
+
+// SyncPlan lists the changes SyncEndpoints made.
+type SyncPlan struct {
+	// Create are the endpoints that were added.
+	Create []EndpointRequest
+	// Update are the endpoints whose settings were changed.
+	Update []EndpointRequest
+	// Delete are the endpoints that were removed.
+	Delete []Endpoint
+}
+
+// Empty reports whether the plan has no changes.
+func (p SyncPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// endpointKey identifies an endpoint of a hostname.
+type endpointKey struct {
+	address string
+	site    string
+}
+
+func keyOf(address IPv6Addr, site string) endpointKey {
+	if site == "" {
+		site = DefaultSite
+	}
+	return endpointKey{address: address.Addr.String(), site: site}
+}
+
+// SyncEndpoints makes the endpoints of the hostname match desired.
+//
+// Endpoints are matched by address and site, with an empty site
+// treated as DefaultSite. Missing endpoints are added, endpoints whose
+// settings differ are updated, and endpoints not in desired are deleted,
+// in that order so the hostname is not left without endpoints.
+// It returns the plan it executed; on error the plan holds the changes
+// that were planned, not all of which may have been made.
+func (s *Service) SyncEndpoints(ctx context.Context, domain, hostname string, desired []EndpointRequest) (SyncPlan, error) {
+	requests, err := normalizeEndpointRequests(domain, hostname, "", "", desired)
+	if err != nil {
+		return SyncPlan{}, err
+	}
+
+	current, _, err := s.GetEndpoints(ctx, domain, hostname, "", "")
+	if err != nil {
+		return SyncPlan{}, err
+	}
+
+	plan, err := planSync(current, requests)
+	if err != nil {
+		return SyncPlan{}, err
+	}
+
+	if len(plan.Create) > 0 {
+		if _, err := s.AddEndpointsForHost(ctx, domain, hostname, plan.Create); err != nil {
+			return plan, err
+		}
+	}
+	for _, update := range plan.Update {
+		address := update.Address.Addr.String()
+		if _, err := s.CreateOrUpdateEndpoints(ctx, domain, hostname, address, update.Site, []EndpointRequest{update}); err != nil {
+			return plan, err
+		}
+	}
+	for _, endpoint := range plan.Delete {
+		if err := s.DeleteEndpoints(ctx, domain, hostname, endpoint.Address.Addr.String(), endpoint.Site); err != nil {
+			return plan, err
+		}
+	}
+
+	return plan, nil
+}
+
+// planSync works out the changes that take current to desired.
+func planSync(current []Endpoint, desired []EndpointRequest) (SyncPlan, error) {
+	existing := make(map[endpointKey]Endpoint, len(current))
+	for _, endpoint := range current {
+		existing[keyOf(endpoint.Address, endpoint.Site)] = endpoint
+	}
+
+	var plan SyncPlan
+	wanted := make(map[endpointKey]bool, len(desired))
+	for _, req := range desired {
+		key := keyOf(req.Address, req.Site)
+		if wanted[key] {
+			return SyncPlan{}, fmt.Errorf("duplicate endpoint %s at site %q", key.address, key.site)
+		}
+		wanted[key] = true
+
+		endpoint, ok := existing[key]
+		switch {
+		case !ok:
+			plan.Create = append(plan.Create, req)
+		case endpoint.ProxyProtocol != req.ProxyProtocol:
+			req.Site = key.site
+			plan.Update = append(plan.Update, req)
+		}
+	}
+
+	for _, endpoint := range current {
+		if !wanted[keyOf(endpoint.Address, endpoint.Site)] {
+			plan.Delete = append(plan.Delete, endpoint)
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,82 @@
+package proxy_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	proxyapi "github.com/paultibbetts/mythicbeasts-client-go/proxy"
+)
+
+func TestSyncEndpoints_OK(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/endpoints/example.com/www", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"endpoints":[
+				{"domain":"example.com","hostname":"www","address":"2a00:1098::1","site":"all","proxy_protocol":false},
+				{"domain":"example.com","hostname":"www","address":"2a00:1098::2","site":"all","proxy_protocol":false},
+				{"domain":"example.com","hostname":"www","address":"2a00:1098::3","site":"all","proxy_protocol":false}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"endpoints":[]}`))
+	})
+	mux.HandleFunc("/endpoints/example.com/www/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"endpoints":[]}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	desired := []proxyapi.EndpointRequest{
+		{Address: proxyapi.IPv6Addr{Addr: mustParseAddr(t, "2a00:1098::1")}},
+		{Address: proxyapi.IPv6Addr{Addr: mustParseAddr(t, "2a00:1098::2")}, Site: "all", ProxyProtocol: true},
+		{Address: proxyapi.IPv6Addr{Addr: mustParseAddr(t, "2a00:1098::4")}, Site: "all"},
+	}
+	plan, err := c.Proxy().SyncEndpoints(testContext(), "example.com", "www", desired)
+	if err != nil {
+		t.Fatalf("SyncEndpoints: %v", err)
+	}
+	if len(plan.Create) != 1 || len(plan.Update) != 1 || len(plan.Delete) != 1 {
+		t.Fatalf("plan=%+v", plan)
+	}
+
+	want := []string{
+		"GET /endpoints/example.com/www",
+		"POST /endpoints/example.com/www",
+		"PUT /endpoints/example.com/www/2a00:1098::2/all",
+		"DELETE /endpoints/example.com/www/2a00:1098::3/all",
+	}
+	if got := strings.Join(calls, "\n"); got != strings.Join(want, "\n") {
+		t.Fatalf("calls:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestSyncEndpoints_NoChanges(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/endpoints/example.com/www", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("method=%s, want GET", r.Method)
+		}
+		_, _ = w.Write([]byte(`{"endpoints":[{"domain":"example.com","hostname":"www","address":"2a00:1098::1","site":"all"}]}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	desired := []proxyapi.EndpointRequest{{Address: proxyapi.IPv6Addr{Addr: mustParseAddr(t, "2a00:1098::1")}}}
+	plan, err := c.Proxy().SyncEndpoints(testContext(), "example.com", "www", desired)
+	if err != nil {
+		t.Fatalf("SyncEndpoints: %v", err)
+	}
+	if !plan.Empty() {
+		t.Fatalf("plan=%+v, want empty", plan)
+	}
+
+	desired = append(desired, desired[0])
+	if _, err := c.Proxy().SyncEndpoints(testContext(), "example.com", "www", desired); err == nil || !strings.Contains(err.Error(), "duplicate endpoint") {
+		t.Fatalf("err=%v, want duplicate error", err)
+	}
+}
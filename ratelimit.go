@@ -0,0 +1,42 @@
+package mythicbeasts
+
+import (
+	"net/http"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
+
+// RateLimit holds the rate limit state reported by the API
+// through the X-RateLimit-* and Retry-After headers.
+type RateLimit = transport.RateLimit
+
+// APIError is returned when the API responds with an unexpected status.
+// It carries the rate limit headers of the failed response.
+type APIError = transport.APIError
+
+// ErrPaymentRequired is returned by provisioning requests when the
+// account does not have the credit to pay for them. It wraps the *APIError.
+type ErrPaymentRequired = transport.ErrPaymentRequired
+
+// ErrQuotaExceeded is returned by provisioning requests that would take
+// the account over one of its limits. It wraps the *APIError.
+type ErrQuotaExceeded = transport.ErrQuotaExceeded
+
+// RateLimit returns the most recent rate limit state reported by the API.
+// It returns false if no response has carried rate limit headers yet.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	c.rateMu.RLock()
+	defer c.rateMu.RUnlock()
+	return c.rateLimit, c.hasRate
+}
+
+func (c *Client) recordRateLimit(res *http.Response) {
+	rl, ok := transport.ParseRateLimit(res.Header)
+	if !ok {
+		return
+	}
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	c.rateLimit = rl
+	c.hasRate = true
+}
@@ -0,0 +1,68 @@
+package mythicbeasts
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDo_RecordsRateLimit(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		w.Header().Set("X-RateLimit-Reset", "1900000000")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "")
+	if _, ok := c.RateLimit(); ok {
+		t.Fatalf("expected no rate limit before any request")
+	}
+
+	if _, err := c.Get(context.Background(), s.URL, "/"); err != nil {
+		t.Fatalf("get error: %v", err)
+	}
+
+	rl, ok := c.RateLimit()
+	if !ok {
+		t.Fatalf("expected rate limit to be recorded")
+	}
+	if rl.Limit != 100 || rl.Remaining != 7 {
+		t.Fatalf("rate limit = %+v, want limit 100 remaining 7", rl)
+	}
+	if !rl.Reset.Equal(time.Unix(1900000000, 0)) {
+		t.Fatalf("reset = %v", rl.Reset)
+	}
+}
+
+func TestDelete_APIErrorCarriesRetryAfter(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte("slow down"))
+	}))
+	t.Cleanup(s.Close)
+
+	c, _ := NewClient("", "")
+	err := c.Delete(context.Background(), s.URL, "/")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", apiErr.StatusCode)
+	}
+	if apiErr.RateLimit.RetryAfter != 30*time.Second {
+		t.Fatalf("retry after = %v, want 30s", apiErr.RateLimit.RetryAfter)
+	}
+	if got, want := err.Error(), "unexpected status 429: slow down"; got != want {
+		t.Fatalf("err = %q, want %q", got, want)
+	}
+}
@@ -1,6 +1,8 @@
 package mythicbeasts
 
 import (
+	"slices"
+
 	"github.com/paultibbetts/mythicbeasts-client-go/pi"
 	"github.com/paultibbetts/mythicbeasts-client-go/proxy"
 	"github.com/paultibbetts/mythicbeasts-client-go/vps"
@@ -24,6 +26,7 @@ func (c *Client) VPS() *vps.Service {
 	}
 	if c.vpsService == nil {
 		c.vpsService = vps.NewService(c)
+		c.vpsService.Protected = slices.Clone(c.protectedVPS)
 	}
 	return c.vpsService
 }
@@ -0,0 +1,81 @@
+package vps
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// parseAddrOrPrefix parses an address that may be written with a
+// prefix length, e.g. "2a00:1098::1" or "2a00:1098::/64".
+// A bare address is returned as a single-address prefix.
+func parseAddrOrPrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix, nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func parseAddrs(values []string, field string, want func(netip.Addr) bool) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		prefix, err := parseAddrOrPrefix(v)
+		if err != nil {
+			return nil, &ErrMalformedResponse{Resource: "server", Field: field, Reason: err.Error()}
+		}
+		if !want(prefix.Addr()) {
+			return nil, &ErrMalformedResponse{Resource: "server", Field: field, Reason: fmt.Sprintf("unexpected address family %q", v)}
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// IPv4Addrs parses the IPv4 addresses of the server.
+func (s Server) IPv4Addrs() ([]netip.Addr, error) {
+	prefixes, err := parseAddrs(s.IPv4, "ipv4", netip.Addr.Is4)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]netip.Addr, len(prefixes))
+	for i, prefix := range prefixes {
+		addrs[i] = prefix.Addr()
+	}
+	return addrs, nil
+}
+
+// IPv6Prefixes parses the IPv6 addresses of the server as prefixes.
+// Ranges such as "2a00:1098::/64" keep their prefix length and
+// single addresses are returned as /128 prefixes.
+func (s Server) IPv6Prefixes() ([]netip.Prefix, error) {
+	return parseAddrs(s.IPv6, "ipv6", func(a netip.Addr) bool { return a.Is6() && !a.Is4In6() })
+}
+
+// IPv6Addrs parses the IPv6 addresses of the server.
+// For ranges the first address of the range is returned.
+func (s Server) IPv6Addrs() ([]netip.Addr, error) {
+	prefixes, err := s.IPv6Prefixes()
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]netip.Addr, len(prefixes))
+	for i, prefix := range prefixes {
+		addrs[i] = prefix.Masked().Addr()
+		if prefix.IsSingleIP() {
+			addrs[i] = prefix.Addr()
+		}
+	}
+	return addrs, nil
+}
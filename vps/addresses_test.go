@@ -0,0 +1,53 @@
+package vps_test
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestServerAddrs(t *testing.T) {
+	t.Parallel()
+	server := vpsapi.Server{
+		IPv4: []string{"192.0.2.10"},
+		IPv6: []string{"2a00:1098:0:82::1", "2a00:1098:0:83::/64"},
+	}
+
+	v4, err := server.IPv4Addrs()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(v4) != 1 || v4[0] != netip.MustParseAddr("192.0.2.10") {
+		t.Fatalf("v4=%v", v4)
+	}
+
+	prefixes, err := server.IPv6Prefixes()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(prefixes) != 2 || prefixes[0].Bits() != 128 || prefixes[1] != netip.MustParsePrefix("2a00:1098:0:83::/64") {
+		t.Fatalf("prefixes=%v", prefixes)
+	}
+
+	v6, err := server.IPv6Addrs()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if v6[0] != netip.MustParseAddr("2a00:1098:0:82::1") || v6[1] != netip.MustParseAddr("2a00:1098:0:83::") {
+		t.Fatalf("v6=%v", v6)
+	}
+}
+
+func TestServerAddrs_Malformed(t *testing.T) {
+	t.Parallel()
+	var malformed *vpsapi.ErrMalformedResponse
+
+	if _, err := (vpsapi.Server{IPv4: []string{"not-an-ip"}}).IPv4Addrs(); !errors.As(err, &malformed) {
+		t.Fatalf("err=%v, want ErrMalformedResponse", err)
+	}
+	if _, err := (vpsapi.Server{IPv6: []string{"192.0.2.1"}}).IPv6Addrs(); !errors.As(err, &malformed) {
+		t.Fatalf("err=%v, want ErrMalformedResponse", err)
+	}
+}
@@ -0,0 +1,114 @@
+package vps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/batch"
+)
+
+// DefaultConcurrency is the default number of concurrent
+// requests made by batch operations.
+const DefaultConcurrency = 4
+
+// CreateSpec describes one VPS provisioned by CreateMany.
+type CreateSpec struct {
+	Identifier string
+	Request    CreateRequest
+}
+
+// CreateManyOptions controls CreateMany.
+type CreateManyOptions struct {
+	// Concurrency is the number of VPSes provisioned at once.
+	// If Concurrency <= 0, DefaultConcurrency is used.
+	Concurrency int
+	// FailFast stops starting new VPSes after the first failure.
+	// VPSes already being provisioned are still waited for.
+	FailFast bool
+	// Create is passed to Create for every VPS.
+	Create CreateOptions
+}
+
+// CreateResult is the outcome of provisioning one VPS.
+type CreateResult struct {
+	Identifier string
+	Server     Server
+	// Err is the provisioning error, if any.
+	Err error
+}
+
+// CreateMany provisions several VPSes concurrently.
+//
+// It returns a result for every spec, in the order given, and an error
+// joining every failure, each prefixed with its identifier.
+// With FailFast set, specs that were not started report ErrSkipped.
+func (s *Service) CreateMany(ctx context.Context, specs []CreateSpec, opts CreateManyOptions) ([]CreateResult, error) {
+	results := make([]CreateResult, len(specs))
+	for i, spec := range specs {
+		results[i].Identifier = spec.Identifier
+	}
+
+	batch.ForEach(ctx, len(specs), concurrencyOrDefault(opts.Concurrency), opts.FailFast, func(ctx context.Context, i int) error {
+		server, err := s.Create(ctx, specs[i].Identifier, specs[i].Request, opts.Create)
+		results[i].Server, results[i].Err = server, err
+		return err
+	}, func(i int) {
+		results[i].Err = ErrSkipped
+	})
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Identifier, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// PowerResult is the outcome of a power operation on one VPS.
+type PowerResult struct {
+	Identifier string
+	Response   PowerResponse
+	// Err is the power operation error, if any.
+	Err error
+}
+
+// SetPowerMany applies the power action to several VPSes concurrently,
+// with at most concurrency requests at once.
+// If concurrency <= 0, DefaultConcurrency is used.
+//
+// It returns a result for every identifier, in the order given, and an
+// error joining every failure, each prefixed with its identifier.
+func (s *Service) SetPowerMany(ctx context.Context, identifiers []string, action PowerAction, concurrency int) ([]PowerResult, error) {
+	if !action.IsValid() {
+		return nil, fmt.Errorf("invalid power action %q", action)
+	}
+
+	results := make([]PowerResult, len(identifiers))
+	for i, identifier := range identifiers {
+		results[i].Identifier = identifier
+	}
+	batch.ForEach(ctx, len(identifiers), concurrencyOrDefault(concurrency), false, func(ctx context.Context, i int) error {
+		results[i].Response, results[i].Err = s.SetPower(ctx, identifiers[i], action)
+		return results[i].Err
+	}, nil)
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Identifier, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// concurrencyOrDefault returns n, or DefaultConcurrency if n <= 0.
+func concurrencyOrDefault(n int) int {
+	if n <= 0 {
+		return DefaultConcurrency
+	}
+	return n
+}
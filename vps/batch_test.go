@@ -0,0 +1,139 @@
+package vps_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func batchMux(t *testing.T, ids ...string) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	for _, id := range ids {
+		mux.HandleFunc("/vps/servers/"+id, func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				w.Header().Set("Location", "/queue/vps/"+id)
+				w.WriteHeader(http.StatusAccepted)
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`{"identifier":"` + id + `","status":"running"}`))
+			}
+		})
+		mux.HandleFunc("/queue/vps/"+id, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"status":"running"}`))
+		})
+	}
+	return mux
+}
+
+func TestCreateMany(t *testing.T) {
+	t.Parallel()
+	mux := batchMux(t, "a", "b", "c")
+	mux.HandleFunc("/vps/servers/taken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	specs := []vpsapi.CreateSpec{
+		{Identifier: "a", Request: vpsapi.CreateRequest{Product: "VPSX4"}},
+		{Identifier: "taken", Request: vpsapi.CreateRequest{Product: "VPSX4"}},
+		{Identifier: "b", Request: vpsapi.CreateRequest{Product: "VPSX4"}},
+		{Identifier: "c", Request: vpsapi.CreateRequest{Product: "VPSX4"}},
+	}
+
+	results, err := c.VPS().CreateMany(testContext(), specs, vpsapi.CreateManyOptions{Concurrency: 2})
+	if err == nil || !strings.Contains(err.Error(), "taken: ") {
+		t.Fatalf("err=%v, want error for taken", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("len(results)=%d, want 4", len(results))
+	}
+	for _, i := range []int{0, 2, 3} {
+		if results[i].Err != nil || results[i].Server.Identifier != specs[i].Identifier {
+			t.Fatalf("results[%d]=%+v", i, results[i])
+		}
+	}
+	var conflict *vpsapi.ErrIdentifierConflict
+	if !errors.As(results[1].Err, &conflict) {
+		t.Fatalf("results[1].Err=%v, want ErrIdentifierConflict", results[1].Err)
+	}
+}
+
+func TestCreateMany_FailFast(t *testing.T) {
+	t.Parallel()
+	var posts atomic.Int32
+	mux := batchMux(t, "b")
+	mux.HandleFunc("/vps/servers/taken", func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+		w.WriteHeader(http.StatusConflict)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	results, err := c.VPS().CreateMany(testContext(), []vpsapi.CreateSpec{
+		{Identifier: "taken"},
+		{Identifier: "b"},
+	}, vpsapi.CreateManyOptions{Concurrency: 1, FailFast: true})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if !errors.Is(results[1].Err, vpsapi.ErrSkipped) {
+		t.Fatalf("results[1].Err=%v, want ErrSkipped", results[1].Err)
+	}
+	if posts.Load() != 1 {
+		t.Fatalf("posts=%d, want 1", posts.Load())
+	}
+}
+
+func TestSetPowerMany(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	for _, id := range []string{"a", "b"} {
+		mux.HandleFunc("/vps/servers/"+id+"/power", func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if r.Method != http.MethodPut {
+				t.Fatalf("method=%s, want PUT", r.Method)
+			}
+			_, _ = w.Write([]byte(`{"message":"shutting down"}`))
+		})
+	}
+	mux.HandleFunc("/vps/servers/gone/power", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	results, err := c.VPS().SetPowerMany(testContext(), []string{"a", "gone", "b", "a"}, vpsapi.PowerActionShutdown, 2)
+	if err == nil || !strings.Contains(err.Error(), "gone: ") {
+		t.Fatalf("err=%v, want error for gone", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("len(results)=%d, want 4", len(results))
+	}
+	for i, id := range []string{"a", "gone", "b", "a"} {
+		if results[i].Identifier != id {
+			t.Fatalf("results[%d].Identifier=%q, want %q", i, results[i].Identifier, id)
+		}
+	}
+	if results[0].Err != nil || results[0].Response.Message != "shutting down" || results[2].Err != nil || results[3].Err != nil {
+		t.Fatalf("results=%+v", results)
+	}
+	if results[1].Err == nil {
+		t.Fatalf("expected error for gone")
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("calls=%d, want 3", calls.Load())
+	}
+
+	if _, err := c.VPS().SetPowerMany(testContext(), []string{"a"}, "reboot", 0); err == nil {
+		t.Fatalf("expected error for invalid action")
+	}
+}
@@ -0,0 +1,113 @@
+package vps
+
+// UpdateCapabilities reports which update fields of a VPS take effect
+// while it is running, which need a power cycle to apply, and which
+// can only be changed while it is powered off.
+type UpdateCapabilities struct {
+	// Hot maps API field names, such as "disk_size" or "extra_ram",
+	// to whether a change is applied without a power cycle.
+	Hot map[string]bool
+	// PoweredOff maps API field names, such as "boot_device",
+	// to whether the VPS must be powered off to change them.
+	PoweredOff map[string]bool
+}
+
+// DefaultUpdateCapabilities returns the capabilities assumed unless
+// the caller passes its own, as the API does not report them: only the name, dormancy, billing period,
+// VNC settings and disk size apply without a power cycle, and the boot
+// device, ISO image, CPU mode, network device, disk bus and tablet can
+// only be changed while the VPS is powered off.
+func DefaultUpdateCapabilities() UpdateCapabilities {
+	return UpdateCapabilities{
+		Hot: map[string]bool{
+			"name":        true,
+			"dormant":     true,
+			"period":      true,
+			"vnc":         true,
+			"disk_size":   true,
+			"product":     false,
+			"extra_cores": false,
+			"extra_ram":   false,
+			"boot_device": false,
+			"iso_image":   false,
+			"cpu_mode":    false,
+			"net_device":  false,
+			"disk_bus":    false,
+			"tablet":      false,
+		},
+		PoweredOff: map[string]bool{
+			"boot_device": true,
+			"iso_image":   true,
+			"cpu_mode":    true,
+			"net_device":  true,
+			"disk_bus":    true,
+			"tablet":      true,
+		},
+	}
+}
+
+// CanHotApply reports whether a change to the field applies without
+// a power cycle. Unknown fields are assumed to need one.
+func (c UpdateCapabilities) CanHotApply(field string) bool {
+	return c.Hot[field]
+}
+
+// RequiresPowerCycle reports whether any field set in req needs
+// a power cycle to take effect.
+func (c UpdateCapabilities) RequiresPowerCycle(req UpdateRequest) bool {
+	for _, field := range req.Fields() {
+		if !c.CanHotApply(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresPoweredOff reports whether any field set in req can only
+// be changed while the VPS is powered off.
+func (c UpdateCapabilities) RequiresPoweredOff(req UpdateRequest) bool {
+	for _, field := range req.Fields() {
+		if c.PoweredOff[field] {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields returns the API names of the fields set in the request,
+// with specs flattened to "disk_size", "extra_cores" and "extra_ram".
+func (r UpdateRequest) Fields() []string {
+	var fields []string
+	add := func(set bool, name string) {
+		if set {
+			fields = append(fields, name)
+		}
+	}
+
+	add(r.Product != nil, "product")
+	if r.Specs != nil {
+		add(r.Specs.DiskSize != nil, "disk_size")
+		add(r.Specs.ExtraCores != nil, "extra_cores")
+		add(r.Specs.ExtraRAM != nil, "extra_ram")
+	}
+	add(r.Name != nil || r.clearName, "name")
+	add(r.BootDevice != nil, "boot_device")
+	add(r.ISOImage != nil || r.clearISOImage, "iso_image")
+	add(r.CPUMode != nil, "cpu_mode")
+	add(r.NetDevice != nil, "net_device")
+	add(r.DiskBus != nil, "disk_bus")
+	add(r.Tablet != nil, "tablet")
+	add(r.Dormant != nil, "dormant")
+	add(r.VNC != nil, "vnc")
+	add(r.Period != nil, "period")
+
+	return fields
+}
+
+// capabilitiesOrDefault returns *caps, or DefaultUpdateCapabilities if caps is nil.
+func capabilitiesOrDefault(caps *UpdateCapabilities) UpdateCapabilities {
+	if caps == nil {
+		return DefaultUpdateCapabilities()
+	}
+	return *caps
+}
@@ -0,0 +1,86 @@
+package vps_test
+
+import (
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestUpdateCapabilities(t *testing.T) {
+	t.Parallel()
+	caps := vpsapi.DefaultUpdateCapabilities()
+	caps.Hot["extra_ram"] = true
+	caps.PoweredOff["cpu_mode"] = false
+
+	if !caps.CanHotApply("extra_ram") || !caps.CanHotApply("disk_size") || caps.CanHotApply("extra_cores") {
+		t.Fatalf("caps=%+v", caps)
+	}
+
+	mode := vpsapi.NewUpdateRequest()
+	mode.SetCPUMode("performance")
+	if caps.RequiresPoweredOff(mode) || !vpsapi.DefaultUpdateCapabilities().RequiresPoweredOff(mode) {
+		t.Fatalf("RequiresPoweredOff=%v, want false and true by default", caps.RequiresPoweredOff(mode))
+	}
+
+	req := vpsapi.NewUpdateRequest()
+	specs := vpsapi.NewUpdateSpecs()
+	specs.SetExtraRAM(2048)
+	req.SetSpecs(specs)
+	if caps.RequiresPowerCycle(req) {
+		t.Fatalf("RequiresPowerCycle=true, want false")
+	}
+	if !vpsapi.DefaultUpdateCapabilities().RequiresPowerCycle(req) {
+		t.Fatalf("default RequiresPowerCycle=false, want true")
+	}
+}
+
+func TestUpdateRequest_Fields(t *testing.T) {
+	t.Parallel()
+	req := vpsapi.NewUpdateRequest()
+	req.SetName("web")
+	specs := vpsapi.NewUpdateSpecs()
+	specs.SetDiskSize(20480)
+	req.SetSpecs(specs)
+	req.ClearISOImage()
+
+	got := req.Fields()
+	want := []string{"disk_size", "name", "iso_image"}
+	if len(got) != len(want) {
+		t.Fatalf("fields=%v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("fields=%v, want %v", got, want)
+		}
+	}
+}
+
+func TestUpdatePoweredOff_UsesCapabilities(t *testing.T) {
+	t.Parallel()
+	patches := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/hot/power", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected power change")
+	})
+	mux.HandleFunc("/vps/servers/hot", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Fatalf("method=%s, want PATCH", r.Method)
+		}
+		patches++
+		_, _ = w.Write([]byte(`{"message":"updated"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	caps := vpsapi.DefaultUpdateCapabilities()
+	caps.PoweredOff["cpu_mode"] = false
+	req := vpsapi.NewUpdateRequest()
+	req.SetCPUMode("performance")
+	if _, err := c.VPS().UpdatePoweredOff(testContext(), "hot", req, vpsapi.PoweredOffUpdateOptions{Capabilities: &caps}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if patches != 1 {
+		t.Fatalf("patches=%d, want 1", patches)
+	}
+}
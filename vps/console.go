@@ -0,0 +1,106 @@
+package vps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ErrVNCUnavailable is returned when a VPS has no VNC
+// address or port to connect to.
+var ErrVNCUnavailable = errors.New("vnc is not available")
+
+// ErrSSHProxyUnavailable is returned when a VPS has no
+// SSH proxy to connect through.
+var ErrSSHProxyUnavailable = errors.New("ssh proxy is not available")
+
+// VNCTarget represents a ready-to-dial VNC console.
+type VNCTarget struct {
+	// Addr is the host:port to connect to.
+	Addr     string
+	Mode     VNCMode
+	Password string
+}
+
+// Target returns the VNC console address of the VPS.
+// The IPv4 address is preferred over the IPv6 address.
+// Returns ErrVNCUnavailable if there is no address or port.
+func (v VNC) Target() (VNCTarget, error) {
+	host := v.IPv4
+	if host == "" {
+		host = v.IPv6
+	}
+	if host == "" || v.Port == 0 {
+		return VNCTarget{}, ErrVNCUnavailable
+	}
+
+	return VNCTarget{
+		Addr:     net.JoinHostPort(host, strconv.FormatInt(v.Port, 10)),
+		Mode:     v.Mode,
+		Password: v.Password,
+	}, nil
+}
+
+// Addr returns the host:port of the SSH proxy.
+// Returns ErrSSHProxyUnavailable if there is no hostname or port.
+func (p SSHProxy) Addr() (string, error) {
+	if p.Hostname == "" || p.Port == 0 {
+		return "", ErrSSHProxyUnavailable
+	}
+	return net.JoinHostPort(p.Hostname, strconv.FormatInt(p.Port, 10)), nil
+}
+
+// Command returns an ssh command line that connects to the VPS
+// through the SSH proxy. If user is empty the ssh default is used.
+// Returns ErrSSHProxyUnavailable if there is no hostname or port.
+func (p SSHProxy) Command(user string) (string, error) {
+	if _, err := p.Addr(); err != nil {
+		return "", err
+	}
+
+	host := p.Hostname
+	if user != "" {
+		host = user + "@" + host
+	}
+	return fmt.Sprintf("ssh -p %d %s", p.Port, host), nil
+}
+
+// ConfigStanza returns an ssh_config Host entry named alias
+// that connects to the VPS through the SSH proxy.
+// If user is empty the User line is omitted.
+// Returns ErrSSHProxyUnavailable if there is no hostname or port.
+func (p SSHProxy) ConfigStanza(alias, user string) (string, error) {
+	if _, err := p.Addr(); err != nil {
+		return "", err
+	}
+	if alias == "" {
+		alias = p.Hostname
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Host %s\n", alias)
+	fmt.Fprintf(&b, "    HostName %s\n", p.Hostname)
+	fmt.Fprintf(&b, "    Port %d\n", p.Port)
+	if user != "" {
+		fmt.Fprintf(&b, "    User %s\n", user)
+	}
+	return b.String(), nil
+}
+
+// UpdateVNC changes the VNC mode and password of the VPS.
+// An empty mode or password is left unchanged.
+//
+// Returns ErrEmptyIdentifier if the identifier is blank.
+func (s *Service) UpdateVNC(ctx context.Context, identifier string, mode VNCMode, password string) (UpdateResponse, error) {
+	if mode == "" && password == "" {
+		return UpdateResponse{}, errors.New("vnc mode or password is required")
+	}
+
+	req := NewUpdateRequest()
+	req.SetVNC(VNCSettings{Mode: mode, Password: password})
+
+	return s.Update(ctx, identifier, req)
+}
@@ -0,0 +1,104 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestVNCTarget(t *testing.T) {
+	t.Parallel()
+
+	target, err := vpsapi.VNC{Mode: "public", Password: "secret", IPv6: "2a00:1098::1", Port: 5901}.Target()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if target.Addr != "[2a00:1098::1]:5901" || target.Password != "secret" || target.Mode != "public" {
+		t.Fatalf("target=%+v", target)
+	}
+
+	target, err = vpsapi.VNC{IPv4: "192.0.2.1", IPv6: "2a00:1098::1", Port: 5901}.Target()
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if target.Addr != "192.0.2.1:5901" {
+		t.Fatalf("addr=%q, want 192.0.2.1:5901", target.Addr)
+	}
+
+	if _, err := (vpsapi.VNC{Mode: "disabled"}).Target(); !errors.Is(err, vpsapi.ErrVNCUnavailable) {
+		t.Fatalf("err=%v, want ErrVNCUnavailable", err)
+	}
+}
+
+func TestSSHProxyCommand(t *testing.T) {
+	t.Parallel()
+	proxy := vpsapi.SSHProxy{Hostname: "vps-box.vs.mythic-beasts.com", Port: 2201}
+
+	cmd, err := proxy.Command("root")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if cmd != "ssh -p 2201 root@vps-box.vs.mythic-beasts.com" {
+		t.Fatalf("cmd=%q", cmd)
+	}
+
+	if _, err := (vpsapi.SSHProxy{}).Command("root"); !errors.Is(err, vpsapi.ErrSSHProxyUnavailable) {
+		t.Fatalf("err=%v, want ErrSSHProxyUnavailable", err)
+	}
+}
+
+func TestSSHProxyConfigStanza(t *testing.T) {
+	t.Parallel()
+	proxy := vpsapi.SSHProxy{Hostname: "vps-box.vs.mythic-beasts.com", Port: 2201}
+
+	stanza, err := proxy.ConfigStanza("box", "root")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := "Host box\n    HostName vps-box.vs.mythic-beasts.com\n    Port 2201\n    User root\n"
+	if stanza != want {
+		t.Fatalf("stanza=%q, want %q", stanza, want)
+	}
+}
+
+func TestUpdateVNC(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Fatalf("method=%s, want PATCH", r.Method)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		vnc, ok := body["vnc"].(map[string]any)
+		if !ok || vnc["mode"] != "public" || vnc["password"] != "secret" || len(body) != 1 {
+			t.Fatalf("body=%v", body)
+		}
+		_, _ = w.Write([]byte(`{"message":"VNC settings updated"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	res, err := c.VPS().UpdateVNC(testContext(), "box", "public", "secret")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if res.Message != "VNC settings updated" {
+		t.Fatalf("message=%q", res.Message)
+	}
+}
+
+func TestUpdateVNC_NothingToUpdate(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, http.NewServeMux())
+	defer srv.Close()
+
+	if _, err := c.VPS().UpdateVNC(testContext(), "box", "", ""); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,154 @@
+package vps
+
+import "errors"
+
+// CPUMode represents the CPU mode of a VPS.
+type CPUMode string
+
+const (
+	CPUModePerformance   CPUMode = "performance"
+	CPUModeCompatibility CPUMode = "compatibility"
+)
+
+// IsValid reports whether the CPU mode is accepted by the API.
+func (m CPUMode) IsValid() bool {
+	switch m {
+	case CPUModePerformance, CPUModeCompatibility:
+		return true
+	default:
+		return false
+	}
+}
+
+// NetDevice represents the emulated network device of a VPS.
+type NetDevice string
+
+const (
+	NetDeviceVirtio  NetDevice = "virtio"
+	NetDeviceE1000   NetDevice = "e1000"
+	NetDeviceRTL8139 NetDevice = "rtl8139"
+	NetDeviceNE2KPCI NetDevice = "ne2k_pci"
+)
+
+// IsValid reports whether the network device is accepted by the API.
+func (d NetDevice) IsValid() bool {
+	switch d {
+	case NetDeviceVirtio, NetDeviceE1000, NetDeviceRTL8139, NetDeviceNE2KPCI:
+		return true
+	default:
+		return false
+	}
+}
+
+// DiskBus represents the emulated disk bus of a VPS.
+type DiskBus string
+
+const (
+	DiskBusVirtio DiskBus = "virtio"
+	DiskBusSATA   DiskBus = "sata"
+	DiskBusSCSI   DiskBus = "scsi"
+	DiskBusIDE    DiskBus = "ide"
+)
+
+// IsValid reports whether the disk bus is accepted by the API.
+func (b DiskBus) IsValid() bool {
+	switch b {
+	case DiskBusVirtio, DiskBusSATA, DiskBusSCSI, DiskBusIDE:
+		return true
+	default:
+		return false
+	}
+}
+
+// BootDevice represents the device a VPS boots from.
+type BootDevice string
+
+const (
+	BootDeviceHD    BootDevice = "hd"
+	BootDeviceCDROM BootDevice = "cdrom"
+)
+
+// IsValid reports whether the boot device is accepted by the API.
+func (d BootDevice) IsValid() bool {
+	switch d {
+	case BootDeviceHD, BootDeviceCDROM:
+		return true
+	default:
+		return false
+	}
+}
+
+// VNCMode represents how the VNC console of a VPS can be reached.
+type VNCMode string
+
+const (
+	VNCModeDisabled  VNCMode = "disabled"
+	VNCModeLocalhost VNCMode = "localhost"
+	VNCModePublic    VNCMode = "public"
+)
+
+// IsValid reports whether the VNC mode is accepted by the API.
+func (m VNCMode) IsValid() bool {
+	switch m {
+	case VNCModeDisabled, VNCModeLocalhost, VNCModePublic:
+		return true
+	default:
+		return false
+	}
+}
+
+// validEnum returns an ErrInvalidValue if v is set but not valid.
+func validEnum[T ~string](field string, v T, valid func(T) bool) error {
+	if v == "" || valid(v) {
+		return nil
+	}
+	return &ErrInvalidValue{Field: field, Value: string(v)}
+}
+
+// validEnumPtr is validEnum for optional update fields.
+func validEnumPtr[T ~string](field string, v *T, valid func(T) bool) error {
+	if v == nil {
+		return nil
+	}
+	return validEnum(field, *v, valid)
+}
+
+// validateEnums checks the enumerated fields of the request.
+func (r CreateRequest) validateEnums() error {
+	errs := []error{
+		validEnum("cpu_mode", r.CPUMode, CPUMode.IsValid),
+		validEnum("net_device", r.NetDevice, NetDevice.IsValid),
+		validEnum("disk_bus", r.DiskBus, DiskBus.IsValid),
+	}
+	if r.VNC != nil {
+		errs = append(errs, validEnum("vnc mode", r.VNC.Mode, VNCMode.IsValid))
+	}
+	errs = append(errs, validPeriod(r.Period))
+	return errors.Join(errs...)
+}
+
+// validateEnums checks the enumerated fields of the request.
+func (r UpdateRequest) validateEnums() error {
+	errs := []error{
+		validEnumPtr("boot_device", r.BootDevice, BootDevice.IsValid),
+		validEnumPtr("cpu_mode", r.CPUMode, CPUMode.IsValid),
+		validEnumPtr("net_device", r.NetDevice, NetDevice.IsValid),
+		validEnumPtr("disk_bus", r.DiskBus, DiskBus.IsValid),
+	}
+	if r.VNC != nil {
+		errs = append(errs, validEnum("vnc mode", r.VNC.Mode, VNCMode.IsValid))
+	}
+	if r.Period != nil {
+		errs = append(errs, validPeriod(*r.Period))
+	}
+	return errors.Join(errs...)
+}
+
+// validPeriod returns an ErrInvalidProductPeriod if the billing period
+// is set but not one a VPS can be billed for.
+func validPeriod(p ProductPeriod) error {
+	if p == "" || (p.Valid() && p != ProductPeriodAll) {
+		return nil
+	}
+	return &ErrInvalidProductPeriod{Period: p}
+}
@@ -0,0 +1,68 @@
+package vps_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestDeviceEnums_IsValid(t *testing.T) {
+	t.Parallel()
+
+	if !vpsapi.CPUModePerformance.IsValid() || vpsapi.CPUMode("turbo").IsValid() {
+		t.Fatalf("unexpected CPUMode validity")
+	}
+	if !vpsapi.NetDeviceE1000.IsValid() || vpsapi.NetDevice("e100").IsValid() {
+		t.Fatalf("unexpected NetDevice validity")
+	}
+	if !vpsapi.DiskBusSATA.IsValid() || vpsapi.DiskBus("nvme").IsValid() {
+		t.Fatalf("unexpected DiskBus validity")
+	}
+	if !vpsapi.BootDeviceCDROM.IsValid() || vpsapi.BootDevice("usb").IsValid() {
+		t.Fatalf("unexpected BootDevice validity")
+	}
+	if !vpsapi.VNCModePublic.IsValid() || vpsapi.VNCMode("open").IsValid() {
+		t.Fatalf("unexpected VNCMode validity")
+	}
+}
+
+func TestCreate_InvalidEnum(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.VPS().CreateAsync(testContext(), "box", vpsapi.CreateRequest{
+		Product:   "VPSX4",
+		DiskSize:  10240,
+		NetDevice: "virtoi",
+	})
+	var invalid *vpsapi.ErrInvalidValue
+	if !errors.As(err, &invalid) || invalid.Field != "net_device" || invalid.Value != "virtoi" {
+		t.Fatalf("err=%v, want ErrInvalidValue for net_device", err)
+	}
+}
+
+func TestUpdate_InvalidEnum(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	req := vpsapi.NewUpdateRequest()
+	req.SetDiskBus("nvme")
+
+	_, err := c.VPS().Update(testContext(), "box", req)
+	var invalid *vpsapi.ErrInvalidValue
+	if !errors.As(err, &invalid) || invalid.Field != "disk_bus" {
+		t.Fatalf("err=%v, want ErrInvalidValue for disk_bus", err)
+	}
+}
@@ -1,6 +1,11 @@
 package vps
 
-import "context"
+import (
+	"context"
+	"slices"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
 
 // DiskSizes represents the available disk sizes for a VPS.
 type DiskSizes struct {
@@ -11,9 +16,56 @@ type DiskSizes struct {
 // GetDiskSizes retrieves the available disk sizes.
 func (s *Service) GetDiskSizes(ctx context.Context) (*DiskSizes, error) {
 	var result DiskSizes
-	if _, _, err := s.GetJSON(ctx, "/vps/disk-sizes", &result); err != nil {
+	if _, _, err := s.GetJSON(transport.Cacheable(ctx), "/vps/disk-sizes", &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
+
+// Sizes returns the available sizes for the disk type.
+// An empty disk type is treated as SSD.
+func (d DiskSizes) Sizes(diskType string) []int64 {
+	if diskType == "hdd" {
+		return d.HDD
+	}
+	return d.SSD
+}
+
+// Contains reports whether size is available for the disk type.
+func (d DiskSizes) Contains(diskType string, size int64) bool {
+	return slices.Contains(d.Sizes(diskType), size)
+}
+
+// Nearest returns the available size for the disk type closest to size,
+// preferring the larger size on a tie.
+// It reports false if there are no sizes for the disk type.
+func (d DiskSizes) Nearest(diskType string, size int64) (int64, bool) {
+	var (
+		nearest int64
+		found   bool
+	)
+	for _, s := range d.Sizes(diskType) {
+		if !found || closer(s, nearest, size) {
+			nearest, found = s, true
+		}
+	}
+	return nearest, found
+}
+
+// closer reports whether a is closer to target than b,
+// preferring the larger of the two on a tie.
+func closer(a, b, target int64) bool {
+	da, db := abs(a-target), abs(b-target)
+	if da != db {
+		return da < db
+	}
+	return a > b
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,46 @@
+package vps_test
+
+import (
+	"strings"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestDiskSizes_ContainsAndNearest(t *testing.T) {
+	t.Parallel()
+	sizes := vpsapi.DiskSizes{SSD: []int64{5120, 10240, 20480}, HDD: []int64{102400}}
+
+	if !sizes.Contains("", 10240) || sizes.Contains("ssd", 15000) || !sizes.Contains("hdd", 102400) {
+		t.Fatalf("unexpected Contains results")
+	}
+
+	for _, tc := range []struct {
+		size int64
+		want int64
+	}{
+		{size: 1, want: 5120},
+		{size: 11000, want: 10240},
+		{size: 15360, want: 20480},
+		{size: 50000, want: 20480},
+	} {
+		got, ok := sizes.Nearest("ssd", tc.size)
+		if !ok || got != tc.want {
+			t.Fatalf("Nearest(%d)=%d,%v, want %d", tc.size, got, ok, tc.want)
+		}
+	}
+
+	if _, ok := (vpsapi.DiskSizes{}).Nearest("hdd", 1); ok {
+		t.Fatalf("expected no nearest size")
+	}
+}
+
+func TestCreateRequest_ValidateDiskSize_NamesNearest(t *testing.T) {
+	t.Parallel()
+	sizes := vpsapi.DiskSizes{SSD: []int64{5120, 10240}}
+
+	err := (vpsapi.CreateRequest{DiskSize: 10000}).ValidateDiskSize(sizes)
+	if err == nil || !strings.Contains(err.Error(), "nearest is 10240") {
+		t.Fatalf("err=%v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package vps
+
+import "context"
+
+// MakeDormant puts an on-demand VPS into the dormant state,
+// where it is powered off and billed at a reduced rate.
+func (s *Service) MakeDormant(ctx context.Context, identifier string) (UpdateResponse, error) {
+	req := NewUpdateRequest()
+	req.SetDormant(true)
+	return s.Update(ctx, identifier, req)
+}
+
+// Wake takes a VPS out of the dormant state.
+// The VPS is not powered on; use SetPower to start it.
+func (s *Service) Wake(ctx context.Context, identifier string) (UpdateResponse, error) {
+	req := NewUpdateRequest()
+	req.SetDormant(false)
+	return s.Update(ctx, identifier, req)
+}
+
+// MakeDormantAndWait puts the VPS into the dormant state and waits
+// until it reports being dormant.
+func (s *Service) MakeDormantAndWait(ctx context.Context, identifier string, opts WaitOptions) (Server, error) {
+	if _, err := s.MakeDormant(ctx, identifier); err != nil {
+		return Server{}, err
+	}
+
+	return s.waitFor(ctx, identifier, "dormant", opts, func(server Server) bool {
+		return server.Dormant
+	})
+}
+
+// WakeAndWait takes the VPS out of the dormant state and waits
+// until it no longer reports being dormant.
+func (s *Service) WakeAndWait(ctx context.Context, identifier string, opts WaitOptions) (Server, error) {
+	if _, err := s.Wake(ctx, identifier); err != nil {
+		return Server{}, err
+	}
+
+	return s.waitFor(ctx, identifier, "awake", opts, func(server Server) bool {
+		return !server.Dormant
+	})
+}
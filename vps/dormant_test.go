@@ -0,0 +1,55 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func dormantMux(t *testing.T, dormant *bool) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if len(body) != 1 {
+				t.Fatalf("body=%v, want only dormant", body)
+			}
+			*dormant = body["dormant"].(bool)
+			_, _ = w.Write([]byte(`{"message":"updated"}`))
+		case http.MethodGet:
+			_, _ = fmt.Fprintf(w, `{"identifier":"box","dormant":%t}`, *dormant)
+		}
+	})
+	return mux
+}
+
+func TestMakeDormantAndWake(t *testing.T) {
+	t.Parallel()
+	dormant := false
+	c, srv := newTestClient(t, dormantMux(t, &dormant))
+	defer srv.Close()
+	opts := vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second}
+
+	server, err := c.VPS().MakeDormantAndWait(testContext(), "box", opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !server.Dormant {
+		t.Fatalf("server=%+v, want dormant", server)
+	}
+
+	server, err = c.VPS().WakeAndWait(testContext(), "box", opts)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Dormant {
+		t.Fatalf("server=%+v, want awake", server)
+	}
+}
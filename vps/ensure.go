@@ -0,0 +1,117 @@
+package vps
+
+import "context"
+
+// EnsureOptions controls Ensure.
+type EnsureOptions struct {
+	// Create is passed to Create when the VPS is missing.
+	Create CreateOptions
+	// PoweredOff controls powering the VPS off for updates
+	// that require it.
+	PoweredOff PoweredOffUpdateOptions
+}
+
+// EnsureResult reports what Ensure did.
+type EnsureResult struct {
+	// Server is the VPS after any changes.
+	Server Server
+	// Created reports whether the VPS was provisioned.
+	Created bool
+	// Changed lists the API names of the fields that were updated.
+	Changed []string
+}
+
+// Ensure makes the VPS match spec.
+//
+// A missing VPS is provisioned with Create. An existing VPS is compared
+// with spec and only the fields that differ are updated, powering it off
+// first if opts.PoweredOff.Capabilities require it, see UpdatePoweredOff.
+// Fields that can only be set on creation, such as Image, Zone and
+// SSHKeys, are not compared.
+// Empty fields in spec are left unchanged, except ExtraCores and
+// ExtraRAM which are always compared.
+func (s *Service) Ensure(ctx context.Context, identifier string, spec CreateRequest, opts EnsureOptions) (EnsureResult, error) {
+	server, err := s.Get(ctx, identifier)
+	if isNotFound(err) {
+		created, err := s.Create(ctx, identifier, spec, opts.Create)
+		if err != nil {
+			return EnsureResult{}, err
+		}
+		return EnsureResult{Server: created, Created: true}, nil
+	}
+	if err != nil {
+		return EnsureResult{}, err
+	}
+
+	req, changed := ensureUpdate(server, spec)
+	if len(changed) == 0 {
+		return EnsureResult{Server: server}, nil
+	}
+
+	if _, err := s.UpdatePoweredOff(ctx, identifier, req, opts.PoweredOff); err != nil {
+		return EnsureResult{}, err
+	}
+
+	server, err = s.Get(ctx, identifier)
+	if err != nil {
+		return EnsureResult{}, err
+	}
+
+	return EnsureResult{Server: server, Changed: changed}, nil
+}
+
+// ensureUpdate builds the update that takes server to spec and
+// lists the fields it changes.
+func ensureUpdate(server Server, spec CreateRequest) (UpdateRequest, []string) {
+	req := NewUpdateRequest()
+	var changed []string
+
+	if spec.Product != "" && spec.Product != server.Product {
+		req.SetProduct(spec.Product)
+		changed = append(changed, "product")
+	}
+	if spec.Name != "" && spec.Name != server.Name {
+		req.SetName(spec.Name)
+		changed = append(changed, "name")
+	}
+
+	specs := NewUpdateSpecs()
+	specsChanged := false
+	if spec.DiskSize > 0 && spec.DiskSize != server.Specs.DiskSize {
+		specs.SetDiskSize(spec.DiskSize)
+		specsChanged = true
+		changed = append(changed, "disk_size")
+	}
+	if spec.ExtraCores != server.Specs.ExtraCores {
+		specs.SetExtraCores(spec.ExtraCores)
+		specsChanged = true
+		changed = append(changed, "extra_cores")
+	}
+	if spec.ExtraRAM != server.Specs.ExtraRAM {
+		specs.SetExtraRAM(spec.ExtraRAM)
+		specsChanged = true
+		changed = append(changed, "extra_ram")
+	}
+	if specsChanged {
+		req.SetSpecs(specs)
+	}
+
+	if spec.CPUMode != "" && spec.CPUMode != server.CPUMode {
+		req.SetCPUMode(spec.CPUMode)
+		changed = append(changed, "cpu_mode")
+	}
+	if spec.NetDevice != "" && spec.NetDevice != server.NetDevice {
+		req.SetNetDevice(spec.NetDevice)
+		changed = append(changed, "net_device")
+	}
+	if spec.DiskBus != "" && spec.DiskBus != server.DiskBus {
+		req.SetDiskBus(spec.DiskBus)
+		changed = append(changed, "disk_bus")
+	}
+	if spec.Tablet != nil && *spec.Tablet != server.Tablet {
+		req.SetTablet(*spec.Tablet)
+		changed = append(changed, "tablet")
+	}
+
+	return req, changed
+}
@@ -0,0 +1,134 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+type fakeEnsure struct {
+	t      *testing.T
+	server vpsapi.Server
+	calls  []string
+}
+
+func (f *fakeEnsure) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box/power", func(w http.ResponseWriter, r *http.Request) {
+		var req vpsapi.PowerRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.calls = append(f.calls, string(req.Power))
+		f.server.Status = "powered off"
+		if req.Power == vpsapi.PowerActionOn {
+			f.server.Status = "running"
+		}
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(f.server)
+		case http.MethodPatch:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.calls = append(f.calls, "patch")
+			if mode, ok := body["cpu_mode"].(string); ok {
+				if f.server.Status != "powered off" {
+					f.t.Fatalf("cpu_mode changed while %q", f.server.Status)
+				}
+				f.server.CPUMode = vpsapi.CPUMode(mode)
+			}
+			if name, ok := body["name"].(string); ok {
+				f.server.Name = name
+			}
+			_, _ = w.Write([]byte(`{"message":"updated"}`))
+		}
+	})
+	return mux
+}
+
+func TestEnsure_UpdatesDifferences(t *testing.T) {
+	t.Parallel()
+	f := &fakeEnsure{t: t, server: vpsapi.Server{
+		Identifier: "box",
+		Name:       "old",
+		Status:     "running",
+		Product:    "VPSX4",
+		CPUMode:    vpsapi.CPUModeCompatibility,
+	}}
+	c, srv := newTestClient(t, f.mux())
+	defer srv.Close()
+
+	spec := vpsapi.CreateRequest{Product: "VPSX4", Name: "new", CPUMode: vpsapi.CPUModePerformance}
+	result, err := c.VPS().Ensure(testContext(), "box", spec, vpsapi.EnsureOptions{
+		PoweredOff: vpsapi.PoweredOffUpdateOptions{Wait: vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second}},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if result.Created {
+		t.Fatalf("unexpected create")
+	}
+	if fmt.Sprint(result.Changed) != "[name cpu_mode]" {
+		t.Fatalf("changed=%v, want [name cpu_mode]", result.Changed)
+	}
+	if result.Server.Name != "new" || result.Server.CPUMode != vpsapi.CPUModePerformance {
+		t.Fatalf("server=%+v", result.Server)
+	}
+	if fmt.Sprint(f.calls) != "[power-off patch power-on]" {
+		t.Fatalf("calls=%v", f.calls)
+	}
+}
+
+func TestEnsure_NoChanges(t *testing.T) {
+	t.Parallel()
+	f := &fakeEnsure{t: t, server: vpsapi.Server{Identifier: "box", Name: "box", Status: "running", Product: "VPSX4"}}
+	c, srv := newTestClient(t, f.mux())
+	defer srv.Close()
+
+	result, err := c.VPS().Ensure(testContext(), "box", vpsapi.CreateRequest{Product: "VPSX4"}, vpsapi.EnsureOptions{})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(result.Changed) != 0 || len(f.calls) != 0 {
+		t.Fatalf("changed=%v calls=%v, want none", result.Changed, f.calls)
+	}
+}
+
+func TestEnsure_CreatesMissing(t *testing.T) {
+	t.Parallel()
+	created := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/new", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			created = true
+			w.Header().Set("Location", "/queue/vps/new")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			if !created {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"identifier":"new","status":"running"}`))
+		}
+	})
+	mux.HandleFunc("/queue/vps/new", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"running"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	result, err := c.VPS().Ensure(testContext(), "new", vpsapi.CreateRequest{Product: "VPSX4"}, vpsapi.EnsureOptions{})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !result.Created || result.Server.Identifier != "new" {
+		t.Fatalf("result=%+v", result)
+	}
+}
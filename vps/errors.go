@@ -3,12 +3,26 @@ package vps
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 )
 
 // ErrEmptyIdentifier is returned when an identifier is not used.
 // Identifiers are required for all VPS resources.
 var ErrEmptyIdentifier = errors.New("identifier is required")
 
+// ErrSkipped is reported for batch items that were not started
+// because an earlier item failed.
+var ErrSkipped = errors.New("skipped after an earlier failure")
+
+// ErrCancelUnsupported is returned by ProvisioningJob.Cancel when the
+// queued job cannot be cancelled, e.g. because it has already finished.
+// The VPS is left as it is; delete it to stop it being billed.
+var ErrCancelUnsupported = errors.New("provisioning job cannot be cancelled")
+
 // ErrIdentifierConflict indicates the requested resource identifier
 // has already been used.
 type ErrIdentifierConflict struct {
@@ -43,25 +57,135 @@ func (e *ErrInvalidProductPeriod) Error() string {
 
 // ErrMalformedResponse indicates the API response body did not contain the
 // expected structure or field types.
-type ErrMalformedResponse struct {
-	Resource string
-	Field    string
-	Reason   string
+type ErrMalformedResponse = transport.ErrMalformedResponse
+
+// ErrWaitTimeout indicates a VPS did not reach the wanted
+// state before the wait timed out.
+type ErrWaitTimeout struct {
+	Identifier string
+	Want       string
+	Last       string
 }
 
-func (e *ErrMalformedResponse) Error() string {
-	resource := e.Resource
-	if resource == "" {
-		resource = "response"
-	}
-	if e.Field == "" && e.Reason == "" {
-		return fmt.Sprintf("malformed %s", resource)
-	}
-	if e.Reason == "" {
-		return fmt.Sprintf("malformed %s field %q", resource, e.Field)
-	}
-	if e.Field == "" {
-		return fmt.Sprintf("malformed %s: %s", resource, e.Reason)
+func (e *ErrWaitTimeout) Error() string {
+	return fmt.Sprintf("timed out waiting for vps %q to be %q, last %q", e.Identifier, e.Want, e.Last)
+}
+
+// ErrInvalidValue indicates a request field holds a value
+// the API does not accept.
+type ErrInvalidValue struct {
+	Field string
+	Value string
+}
+
+func (e *ErrInvalidValue) Error() string {
+	return fmt.Sprintf("invalid %s: %q", e.Field, e.Value)
+}
+
+// ErrProductNotFound indicates the requested product code
+// could not be found.
+type ErrProductNotFound struct {
+	Code string
+}
+
+func (e *ErrProductNotFound) Error() string {
+	return fmt.Sprintf("could not find product with the code %q", e.Code)
+}
+
+// ErrProtected indicates a VPS was not deleted because its identifier
+// matches a Service.Protected pattern. See DeleteOptions.
+type ErrProtected struct {
+	Identifier string
+	Pattern    string
+}
+
+func (e *ErrProtected) Error() string {
+	return fmt.Sprintf("vps %q is protected by %q", e.Identifier, e.Pattern)
+}
+
+// ErrPaymentRequired indicates the account does not have the credit
+// or payment details to pay for the request. It wraps the *APIError.
+type ErrPaymentRequired = transport.ErrPaymentRequired
+
+// ErrInsufficientFunds is the former name of ErrPaymentRequired.
+//
+// Deprecated: Use ErrPaymentRequired.
+type ErrInsufficientFunds = ErrPaymentRequired
+
+// ErrQuotaExceeded indicates the request would take the account
+// over one of its limits. It wraps the *APIError.
+type ErrQuotaExceeded = transport.ErrQuotaExceeded
+
+// ErrServerNotFound indicates no VPS has the requested name.
+type ErrServerNotFound struct {
+	Name string
+}
+
+func (e *ErrServerNotFound) Error() string {
+	return fmt.Sprintf("could not find vps with the name %q", e.Name)
+}
+
+// ErrAmbiguousName indicates more than one VPS has the requested name.
+type ErrAmbiguousName struct {
+	Name        string
+	Identifiers []string
+}
+
+func (e *ErrAmbiguousName) Error() string {
+	return fmt.Sprintf("name %q matches vpses %s", e.Name, strings.Join(e.Identifiers, ", "))
+}
+
+// ErrAmbiguousUserData indicates more than one user data snippet
+// has the requested name.
+type ErrAmbiguousUserData struct {
+	Name string
+	IDs  []int64
+}
+
+func (e *ErrAmbiguousUserData) Error() string {
+	ids := make([]string, len(e.IDs))
+	for i, id := range e.IDs {
+		ids[i] = strconv.FormatInt(id, 10)
 	}
-	return fmt.Sprintf("malformed %s field %q: %s", resource, e.Field, e.Reason)
+	return fmt.Sprintf("name %q matches user data %s", e.Name, strings.Join(ids, ", "))
+}
+
+// ErrImageNotFound indicates no image matches the requested query.
+type ErrImageNotFound struct {
+	Query string
+}
+
+func (e *ErrImageNotFound) Error() string {
+	return fmt.Sprintf("could not find an image matching %q", e.Query)
+}
+
+// ErrAmbiguousImage indicates more than one image matches the requested query.
+type ErrAmbiguousImage struct {
+	Query string
+	Names []string
+}
+
+func (e *ErrAmbiguousImage) Error() string {
+	return fmt.Sprintf("query %q matches images %s", e.Query, strings.Join(e.Names, ", "))
+}
+
+// ProvisioningError indicates provisioning a VPS failed.
+// It wraps the error returned by polling.
+type ProvisioningError struct {
+	Identifier string
+	// Reason is the failure reason given by the API.
+	Reason string
+	Err    error
+}
+
+func (e *ProvisioningError) Error() string {
+	return fmt.Sprintf("provisioning vps %q failed: %s", e.Identifier, e.Reason)
+}
+
+func (e *ProvisioningError) Unwrap() error { return e.Err }
+
+// isNotFound reports whether err is an API 404 response.
+func isNotFound(err error) bool {
+	var apiErr *transport.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
 }
@@ -0,0 +1,81 @@
+package vps
+
+import (
+	"fmt"
+	"math"
+)
+
+// hoursPerMonth is the average number of hours in a month,
+// used to convert monthly prices into hourly ones.
+const hoursPerMonth = 730
+
+// CostEstimate represents the expected cost of a VPS.
+// All prices are in pence.
+type CostEstimate struct {
+	Period ProductPeriod
+	// Product, Disk and IPv4 are the monthly cost of each part.
+	Product int64
+	Disk    int64
+	IPv4    int64
+	// Monthly is the total monthly cost.
+	Monthly int64
+	// Total is the cost for the whole billing period,
+	// which for on-demand pricing is a month.
+	Total int64
+}
+
+// Hourly returns the monthly cost spread over the hours in a month.
+func (e CostEstimate) Hourly() float64 {
+	return float64(e.Monthly) / hoursPerMonth
+}
+
+// EstimateCost computes the expected cost of provisioning req
+// for the billing period, using the monthly prices in pricing.
+// If period is empty ProductPeriodOnDemand is used.
+//
+// Pricing only holds on-demand prices, so other periods, whose products
+// are priced separately, return an error rather than a wrong estimate,
+// and ErrInvalidProductPeriod if the period is not valid.
+//
+// The disk is charged per started extent of its disk type, and
+// IPv4 is added when requested. Extra cores and RAM are not
+// included as they are not part of Pricing.
+func EstimateCost(req CreateRequest, pricing Pricing, period ProductPeriod) (CostEstimate, error) {
+	if period == "" {
+		period = ProductPeriodOnDemand
+	}
+	if !period.Valid() {
+		return CostEstimate{}, &ErrInvalidProductPeriod{Period: period}
+	}
+	if period != ProductPeriodOnDemand {
+		return CostEstimate{}, fmt.Errorf("cannot estimate %s cost: pricing only covers on-demand products", period)
+	}
+
+	product, ok := pricing.Products[req.Product]
+	if !ok {
+		return CostEstimate{}, fmt.Errorf("no price for product %q", req.Product)
+	}
+
+	disk := pricing.Disk.SSD
+	if req.DiskType == "hdd" {
+		disk = pricing.Disk.HDD
+	}
+	if disk.Extent <= 0 {
+		return CostEstimate{}, fmt.Errorf("no disk pricing for disk type %q", req.DiskType)
+	}
+	// disk sizes are in MB and extents in GB
+	extents := int64(math.Ceil(float64(req.DiskSize) / 1024 / float64(disk.Extent)))
+
+	estimate := CostEstimate{
+		Period:  period,
+		Product: product,
+		Disk:    extents * disk.Price,
+	}
+	if req.IPv4 {
+		estimate.IPv4 = pricing.IPv4
+	}
+	estimate.Monthly = estimate.Product + estimate.Disk + estimate.IPv4
+	estimate.Total = estimate.Monthly
+
+	return estimate, nil
+}
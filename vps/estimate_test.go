@@ -0,0 +1,61 @@
+package vps_test
+
+import (
+	"errors"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+var testPricing = vpsapi.Pricing{
+	Disk: vpsapi.DiskPrices{
+		SSD: vpsapi.DiskPricing{Price: 50, Extent: 5},
+		HDD: vpsapi.DiskPricing{Price: 20, Extent: 50},
+	},
+	IPv4:     150,
+	Products: map[string]int64{"VPSX4": 1000},
+}
+
+func TestEstimateCost(t *testing.T) {
+	t.Parallel()
+
+	req := vpsapi.CreateRequest{Product: "VPSX4", DiskSize: 12 * 1024, IPv4: true}
+	estimate, err := vpsapi.EstimateCost(req, testPricing, vpsapi.ProductPeriodOnDemand)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	// 12GB of SSD is three started 5GB extents.
+	if estimate.Disk != 150 {
+		t.Fatalf("disk=%d, want 150", estimate.Disk)
+	}
+	if estimate.Monthly != 1300 {
+		t.Fatalf("monthly=%d, want 1300", estimate.Monthly)
+	}
+	if estimate.Total != 1300 {
+		t.Fatalf("total=%d, want 1300", estimate.Total)
+	}
+	if estimate.Hourly() <= 0 {
+		t.Fatalf("hourly=%v, want > 0", estimate.Hourly())
+	}
+}
+
+func TestEstimateCost_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := vpsapi.EstimateCost(vpsapi.CreateRequest{Product: "VPSX99"}, testPricing, ""); err == nil {
+		t.Fatalf("expected error for unknown product")
+	}
+
+	_, err := vpsapi.EstimateCost(vpsapi.CreateRequest{Product: "VPSX4"}, testPricing, "weekly")
+	var invalid *vpsapi.ErrInvalidProductPeriod
+	if !errors.As(err, &invalid) {
+		t.Fatalf("err=%v, want ErrInvalidProductPeriod", err)
+	}
+
+	for _, period := range []vpsapi.ProductPeriod{vpsapi.ProductPeriodMonth, vpsapi.ProductPeriodQuarter, vpsapi.ProductPeriodYear} {
+		if _, err := vpsapi.EstimateCost(vpsapi.CreateRequest{Product: "VPSX4"}, testPricing, period); err == nil {
+			t.Fatalf("%s: expected error for fixed-term period", period)
+		}
+	}
+}
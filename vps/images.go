@@ -1,11 +1,22 @@
 package vps
 
-import "context"
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
 
 // Image represents a VPS operating system image.
 type Image struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// CloudInit reports whether the image runs cloud-init,
+	// so it can be configured with user data.
+	CloudInit bool `json:"cloud_init"`
+	// Architecture is the CPU architecture of the image, e.g. "x86_64".
+	Architecture string `json:"architecture"`
 }
 
 // Images maps image names to image details.
@@ -14,9 +25,114 @@ type Images map[string]Image
 // GetImages retrieves the available operating system images for a VPS.
 func (s *Service) GetImages(ctx context.Context) (Images, error) {
 	var result Images
-	if _, _, err := s.GetJSON(ctx, "/vps/images", &result); err != nil {
+	if _, _, err := s.GetJSON(transport.Cacheable(ctx), "/vps/images", &result); err != nil {
 		return nil, err
 	}
 
 	return result, nil
 }
+
+// Resolve finds the image matching query, such as "ubuntu 24.04".
+//
+// An image whose name equals query is returned as is. Otherwise every
+// word of the query must start a word of the image's name or description,
+// ignoring case. If several images match, cloud-init images are preferred.
+// Returns ErrImageNotFound if nothing matches and ErrAmbiguousImage if
+// more than one image still matches.
+func (images Images) Resolve(query string) (Image, error) {
+	for key, image := range images {
+		if key == query || image.Name == query {
+			return imageWithName(key, image), nil
+		}
+	}
+
+	words := imageWords(query)
+	if len(words) == 0 {
+		return Image{}, &ErrImageNotFound{Query: query}
+	}
+
+	var matches []Image
+	for key, image := range images {
+		image = imageWithName(key, image)
+		if imageMatches(image, words) {
+			matches = append(matches, image)
+		}
+	}
+
+	if len(matches) > 1 {
+		var cloudInit []Image
+		for _, image := range matches {
+			if image.CloudInit {
+				cloudInit = append(cloudInit, image)
+			}
+		}
+		if len(cloudInit) > 0 {
+			matches = cloudInit
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Image{}, &ErrImageNotFound{Query: query}
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, image := range matches {
+			names[i] = image.Name
+		}
+		sort.Strings(names)
+		return Image{}, &ErrAmbiguousImage{Query: query, Names: names}
+	}
+}
+
+// ResolveImage retrieves the available images and returns the one
+// matching query, such as "ubuntu 24.04". See Images.Resolve.
+func (s *Service) ResolveImage(ctx context.Context, query string) (Image, error) {
+	images, err := s.GetImages(ctx)
+	if err != nil {
+		return Image{}, err
+	}
+
+	return images.Resolve(query)
+}
+
+// imageWithName fills in the image name from its key in Images.
+func imageWithName(key string, image Image) Image {
+	if image.Name == "" {
+		image.Name = key
+	}
+	return image
+}
+
+// imageMatches reports whether every query word starts a word
+// of the image's name or description.
+func imageMatches(image Image, query []string) bool {
+	words := imageWords(image.Name + " " + image.Description)
+	for _, q := range query {
+		found := false
+		for _, w := range words {
+			if strings.HasPrefix(w, q) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// imageWords splits s into lower case words, keeping dotted
+// version numbers such as "24.04" together.
+func imageWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.':
+			return false
+		default:
+			return true
+		}
+	})
+}
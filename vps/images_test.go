@@ -0,0 +1,59 @@
+package vps_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestResolveImage(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/images", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"cloudinit-ubuntu-noble.raw.gz": {"name": "cloudinit-ubuntu-noble.raw.gz", "description": "Ubuntu 24.04 (Noble Numbat)", "cloud_init": true, "architecture": "x86_64"},
+			"ubuntu-noble-installer": {"name": "ubuntu-noble-installer", "description": "Ubuntu 24.04 (Noble Numbat) installer"},
+			"cloudinit-debian-bookworm.raw.gz": {"name": "cloudinit-debian-bookworm.raw.gz", "description": "Debian 12 (Bookworm)", "cloud_init": true},
+			"cloudinit-debian-trixie.raw.gz": {"name": "cloudinit-debian-trixie.raw.gz", "description": "Debian 13 (Trixie)", "cloud_init": true}
+		}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		query string
+		want  string
+	}{
+		{query: "ubuntu 24.04", want: "cloudinit-ubuntu-noble.raw.gz"},
+		{query: "Noble installer", want: "ubuntu-noble-installer"},
+		{query: "debian 12", want: "cloudinit-debian-bookworm.raw.gz"},
+		{query: "cloudinit-debian-trixie.raw.gz", want: "cloudinit-debian-trixie.raw.gz"},
+	} {
+		image, err := c.VPS().ResolveImage(testContext(), tc.query)
+		if err != nil {
+			t.Fatalf("%q: err: %v", tc.query, err)
+		}
+		if image.Name != tc.want {
+			t.Fatalf("%q: image=%q, want %q", tc.query, image.Name, tc.want)
+		}
+	}
+
+	image, _ := c.VPS().ResolveImage(testContext(), "ubuntu 24.04")
+	if !image.CloudInit || image.Architecture != "x86_64" {
+		t.Fatalf("image=%+v, want cloud-init x86_64 metadata", image)
+	}
+
+	_, err := c.VPS().ResolveImage(testContext(), "debian")
+	var ambiguous *vpsapi.ErrAmbiguousImage
+	if !errors.As(err, &ambiguous) || len(ambiguous.Names) != 2 {
+		t.Fatalf("err=%v, want ErrAmbiguousImage", err)
+	}
+
+	_, err = c.VPS().ResolveImage(testContext(), "fedora")
+	var notFound *vpsapi.ErrImageNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("err=%v, want ErrImageNotFound", err)
+	}
+}
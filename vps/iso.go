@@ -0,0 +1,92 @@
+package vps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// PoweredOffUpdateOptions controls helpers that power the VPS off
+// to apply an update.
+type PoweredOffUpdateOptions struct {
+	// Wait controls polling while waiting for power state changes.
+	Wait WaitOptions
+	// Shutdown requests an ACPI shutdown instead of a hard power-off.
+	Shutdown bool
+	// Capabilities decides which fields need the VPS powered off.
+	// If Capabilities is nil, DefaultUpdateCapabilities is used.
+	Capabilities *UpdateCapabilities
+}
+
+// AttachISOAndBoot powers the VPS off if needed, sets the ISO image and
+// boots from cdrom, then powers it on.
+// Use RevertToDiskBoot to boot from disk again.
+func (s *Service) AttachISOAndBoot(ctx context.Context, identifier string, isoImage string, opts PoweredOffUpdateOptions) (UpdateResponse, error) {
+	req := NewUpdateRequest()
+	req.SetISOImage(isoImage)
+	req.SetBootDevice(BootDeviceCDROM)
+
+	return s.UpdatePoweredOff(ctx, identifier, req, opts)
+}
+
+// RevertToDiskBoot powers the VPS off if needed, clears the ISO image and
+// boots from hd, then powers it on.
+func (s *Service) RevertToDiskBoot(ctx context.Context, identifier string, opts PoweredOffUpdateOptions) (UpdateResponse, error) {
+	req := NewUpdateRequest()
+	req.ClearISOImage()
+	req.SetBootDevice(BootDeviceHD)
+
+	return s.UpdatePoweredOff(ctx, identifier, req, opts)
+}
+
+// UpdatePoweredOff applies an update that requires the VPS to be powered off.
+// If opts.Capabilities shows every field in req can be changed while
+// the VPS is running, the update is applied without powering it off.
+// Otherwise a running VPS is powered off first, and powered on again
+// afterwards even if the update fails, waiting until it reports being on;
+// a VPS that was already off is left off.
+// An error powering the VPS back on is joined to the returned error.
+func (s *Service) UpdatePoweredOff(ctx context.Context, identifier string, req UpdateRequest, opts PoweredOffUpdateOptions) (resp UpdateResponse, err error) {
+	if !capabilitiesOrDefault(opts.Capabilities).RequiresPoweredOff(req) {
+		return s.Update(ctx, identifier, req)
+	}
+
+	state, err := s.GetPowerStatus(ctx, identifier)
+	if err != nil {
+		return UpdateResponse{}, err
+	}
+
+	if state != PowerStateOff {
+		off := PowerActionOff
+		if opts.Shutdown {
+			off = PowerActionShutdown
+		}
+		if _, err := s.SetPower(ctx, identifier, off); err != nil {
+			return UpdateResponse{}, err
+		}
+		defer func() {
+			if onErr := s.restorePower(ctx, identifier, opts.Wait); onErr != nil {
+				resp, err = UpdateResponse{}, errors.Join(err, onErr)
+			}
+		}()
+
+		if _, err := s.WaitForPowerState(ctx, identifier, PowerStateOff, opts.Wait); err != nil {
+			return UpdateResponse{}, err
+		}
+	}
+
+	return s.Update(ctx, identifier, req)
+}
+
+// restorePower powers the VPS on and waits until it reports being on.
+// The power-on request is made even if ctx has been cancelled,
+// so the VPS is not left off.
+func (s *Service) restorePower(ctx context.Context, identifier string, opts WaitOptions) error {
+	if _, err := s.SetPower(context.WithoutCancel(ctx), identifier, PowerActionOn); err != nil {
+		return fmt.Errorf("powering on %s: %w", identifier, err)
+	}
+	if _, err := s.WaitForPowerState(ctx, identifier, PowerStateOn, opts); err != nil {
+		return fmt.Errorf("powering on %s: %w", identifier, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+type fakeVPS struct {
+	t         *testing.T
+	status    string
+	failPatch bool
+	calls     []string
+	patches   []map[string]any
+}
+
+func (f *fakeVPS) mux(id string) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/"+id+"/power", func(w http.ResponseWriter, r *http.Request) {
+		var req vpsapi.PowerRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.calls = append(f.calls, string(req.Power))
+		if req.Power == vpsapi.PowerActionOn {
+			f.status = "running"
+		} else {
+			f.status = "powered off"
+		}
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	mux.HandleFunc("/vps/servers/"+id, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprintf(w, `{"identifier":%q,"status":%q}`, id, f.status)
+		case http.MethodPatch:
+			if f.status != "powered off" {
+				f.t.Fatalf("PATCH while %q", f.status)
+			}
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.calls = append(f.calls, "patch")
+			f.patches = append(f.patches, body)
+			if f.failPatch {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid iso image"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"message":"updated"}`))
+		}
+	})
+	return mux
+}
+
+func TestAttachISOAndBoot(t *testing.T) {
+	t.Parallel()
+	f := &fakeVPS{t: t, status: "running"}
+	c, srv := newTestClient(t, f.mux("box"))
+	defer srv.Close()
+	opts := vpsapi.PoweredOffUpdateOptions{Wait: vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second}}
+
+	if _, err := c.VPS().AttachISOAndBoot(testContext(), "box", "rescue.iso", opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := c.VPS().RevertToDiskBoot(testContext(), "box", opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	want := []string{"power-off", "patch", "power-on", "power-off", "patch", "power-on"}
+	if fmt.Sprint(f.calls) != fmt.Sprint(want) {
+		t.Fatalf("calls=%v, want %v", f.calls, want)
+	}
+	if f.patches[0]["iso_image"] != "rescue.iso" || f.patches[0]["boot_device"] != "cdrom" {
+		t.Fatalf("attach patch=%v", f.patches[0])
+	}
+	if iso, ok := f.patches[1]["iso_image"]; !ok || iso != nil || f.patches[1]["boot_device"] != "hd" {
+		t.Fatalf("revert patch=%v", f.patches[1])
+	}
+}
+
+func TestUpdatePoweredOff_LeavesOffServerOff(t *testing.T) {
+	t.Parallel()
+	f := &fakeVPS{t: t, status: "powered off"}
+	c, srv := newTestClient(t, f.mux("box"))
+	defer srv.Close()
+
+	req := vpsapi.NewUpdateRequest()
+	req.SetCPUMode("performance")
+	if _, err := c.VPS().UpdatePoweredOff(testContext(), "box", req, vpsapi.PoweredOffUpdateOptions{}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(f.calls) != 1 || f.calls[0] != "patch" {
+		t.Fatalf("calls=%v, want only patch", f.calls)
+	}
+}
+
+func TestUpdatePoweredOff_RestoresPowerOnFailure(t *testing.T) {
+	t.Parallel()
+	f := &fakeVPS{t: t, status: "running", failPatch: true}
+	c, srv := newTestClient(t, f.mux("box"))
+	defer srv.Close()
+	opts := vpsapi.PoweredOffUpdateOptions{Wait: vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second}}
+
+	if _, err := c.VPS().AttachISOAndBoot(testContext(), "box", "missing.iso", opts); err == nil {
+		t.Fatalf("expected update error")
+	}
+	want := []string{"power-off", "patch", "power-on"}
+	if fmt.Sprint(f.calls) != fmt.Sprint(want) {
+		t.Fatalf("calls=%v, want %v", f.calls, want)
+	}
+	if f.status != "running" {
+		t.Fatalf("status=%q, want running", f.status)
+	}
+}
@@ -0,0 +1,104 @@
+package vps
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoHostCapacity is returned by PickHost when no host has
+// enough free RAM and disk for the server.
+var ErrNoHostCapacity = errors.New("no host has enough free capacity")
+
+// HostStrategy reports whether host a is a better choice than
+// host b for a server with the given specs.
+// Both hosts have enough free capacity for the server.
+type HostStrategy func(a, b Host, specs ServerSpecs) bool
+
+// MostFree prefers the host with the most free RAM,
+// spreading servers across hosts.
+func MostFree(a, b Host, specs ServerSpecs) bool {
+	return a.FreeRAM > b.FreeRAM
+}
+
+// BestFit prefers the host with the least free RAM left over,
+// packing servers onto as few hosts as possible.
+func BestFit(a, b Host, specs ServerSpecs) bool {
+	return a.FreeRAM < b.FreeRAM
+}
+
+// Fits reports whether the host has enough free RAM and
+// free disk of the right type for a server with specs.
+// An empty disk type is treated as SSD.
+func (h Host) Fits(specs ServerSpecs) bool {
+	if h.FreeRAM < specs.RAM+specs.ExtraRAM {
+		return false
+	}
+	free := h.FreeDisk.SSD
+	if specs.DiskType == "hdd" {
+		free = h.FreeDisk.HDD
+	}
+	return free >= specs.DiskSize
+}
+
+// PickHost selects the private cloud host for a server with specs.
+// Hosts without enough capacity are skipped, and the rest are compared
+// with strategy; ties are broken by host name.
+// If strategy is nil, MostFree is used.
+// Returns ErrNoHostCapacity if no host fits.
+func PickHost(hosts Hosts, specs ServerSpecs, strategy HostStrategy) (Host, error) {
+	if strategy == nil {
+		strategy = MostFree
+	}
+
+	var (
+		best  Host
+		found bool
+	)
+	for name, host := range hosts {
+		if host.Name == "" {
+			host.Name = name
+		}
+		if !host.Fits(specs) {
+			continue
+		}
+		if found {
+			better := strategy(host, best, specs)
+			tie := !better && !strategy(best, host, specs)
+			if !better && !(tie && host.Name < best.Name) {
+				continue
+			}
+		}
+		best, found = host, true
+	}
+
+	if !found {
+		return Host{}, ErrNoHostCapacity
+	}
+	return best, nil
+}
+
+// placeOnHost sets the host server of req using strategy,
+// looking up the RAM of its product.
+func (s *Service) placeOnHost(ctx context.Context, req *CreateRequest, strategy HostStrategy) error {
+	product, err := s.GetProduct(ctx, req.Product)
+	if err != nil {
+		return err
+	}
+	hosts, err := s.GetHosts(ctx)
+	if err != nil {
+		return err
+	}
+
+	host, err := PickHost(hosts, ServerSpecs{
+		DiskType: req.DiskType,
+		DiskSize: req.DiskSize,
+		RAM:      int64(product.Specs.RAM),
+		ExtraRAM: req.ExtraRAM,
+	}, strategy)
+	if err != nil {
+		return err
+	}
+
+	req.HostServer = host.Name
+	return nil
+}
@@ -0,0 +1,92 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+var testHosts = vpsapi.Hosts{
+	"host1": {Name: "host1", FreeRAM: 8192, FreeDisk: vpsapi.HostDisk{SSD: 51200}},
+	"host2": {Name: "host2", FreeRAM: 32768, FreeDisk: vpsapi.HostDisk{SSD: 512000}},
+	"host3": {Name: "host3", FreeRAM: 65536, FreeDisk: vpsapi.HostDisk{HDD: 1024000}},
+}
+
+func TestPickHost(t *testing.T) {
+	t.Parallel()
+	specs := vpsapi.ServerSpecs{RAM: 4096, DiskSize: 20480}
+
+	host, err := vpsapi.PickHost(testHosts, specs, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if host.Name != "host2" {
+		t.Fatalf("most free host=%q, want host2", host.Name)
+	}
+
+	host, err = vpsapi.PickHost(testHosts, specs, vpsapi.BestFit)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if host.Name != "host1" {
+		t.Fatalf("best fit host=%q, want host1", host.Name)
+	}
+
+	host, err = vpsapi.PickHost(testHosts, vpsapi.ServerSpecs{RAM: 4096, DiskType: "hdd", DiskSize: 102400}, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if host.Name != "host3" {
+		t.Fatalf("hdd host=%q, want host3", host.Name)
+	}
+
+	_, err = vpsapi.PickHost(testHosts, vpsapi.ServerSpecs{RAM: 131072}, nil)
+	if !errors.Is(err, vpsapi.ErrNoHostCapacity) {
+		t.Fatalf("err=%v, want ErrNoHostCapacity", err)
+	}
+}
+
+func TestCreate_PickHost(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/products", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"VPSX16":{"code":"VPSX16","specs":{"cores":4,"ram":16384}}}`))
+	})
+	mux.HandleFunc("/vps/hosts", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(testHosts)
+	})
+	mux.HandleFunc("/vps/servers/placed", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var req vpsapi.CreateRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.HostServer != "host2" {
+				t.Fatalf("host_server=%q, want host2", req.HostServer)
+			}
+			w.Header().Set("Location", "/queue/vps/placed")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"identifier":"placed","status":"running","host_server":"host2"}`))
+		}
+	})
+	mux.HandleFunc("/queue/vps/placed", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"running"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	server, err := c.VPS().Create(testContext(), "placed", vpsapi.CreateRequest{Product: "VPSX16", DiskSize: 20480}, vpsapi.CreateOptions{
+		PickHost: vpsapi.MostFree,
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.HostServer != "host2" {
+		t.Fatalf("host_server=%q, want host2", server.HostServer)
+	}
+}
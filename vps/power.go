@@ -33,6 +33,41 @@ func (p PowerAction) IsValid() bool {
 	}
 }
 
+// PowerState represents whether a VPS is powered on.
+type PowerState string
+
+const (
+	PowerStateOn      PowerState = "on"
+	PowerStateOff     PowerState = "off"
+	PowerStateUnknown PowerState = "unknown"
+)
+
+// PowerState reports the power state implied by the server status.
+// Dormant servers are powered off.
+func (s Server) PowerState() PowerState {
+	if s.Dormant {
+		return PowerStateOff
+	}
+	switch s.Status {
+	case StatusRunning:
+		return PowerStateOn
+	case StatusPoweredOff, StatusStopped, StatusShutoff, StatusDormant:
+		return PowerStateOff
+	default:
+		return PowerStateUnknown
+	}
+}
+
+// GetPowerStatus retrieves the current power state of the VPS.
+func (s *Service) GetPowerStatus(ctx context.Context, identifier string) (PowerState, error) {
+	server, err := s.Get(ctx, identifier)
+	if err != nil {
+		return PowerStateUnknown, err
+	}
+
+	return server.PowerState(), nil
+}
+
 // PowerRequest represents the request payload for a power operation.
 type PowerRequest struct {
 	Power PowerAction `json:"power"`
@@ -115,6 +150,46 @@ func (s *Service) ShutdownWithGrace(ctx context.Context, identifier string, grac
 	return resp, nil
 }
 
+// PowerCycleOptions controls PowerCycle.
+type PowerCycleOptions struct {
+	// Wait controls polling while waiting for power state changes.
+	Wait WaitOptions
+	// Shutdown requests an ACPI shutdown instead of a hard power-off.
+	Shutdown bool
+	// WaitForRunning waits for the VPS to report running after powering on.
+	WaitForRunning bool
+}
+
+// PowerCycle powers the VPS off, waits for it to report powered off,
+// then powers it on again.
+// If opts.WaitForRunning is set it also waits for the VPS to be running.
+func (s *Service) PowerCycle(ctx context.Context, identifier string, opts PowerCycleOptions) (PowerResponse, error) {
+	off := PowerActionOff
+	if opts.Shutdown {
+		off = PowerActionShutdown
+	}
+
+	if _, err := s.SetPower(ctx, identifier, off); err != nil {
+		return PowerResponse{}, err
+	}
+	if _, err := s.WaitForPowerState(ctx, identifier, PowerStateOff, opts.Wait); err != nil {
+		return PowerResponse{}, err
+	}
+
+	resp, err := s.SetPower(ctx, identifier, PowerActionOn)
+	if err != nil {
+		return PowerResponse{}, err
+	}
+
+	if opts.WaitForRunning {
+		if _, err := s.WaitForPowerState(ctx, identifier, PowerStateOn, opts.Wait); err != nil {
+			return PowerResponse{}, err
+		}
+	}
+
+	return resp, nil
+}
+
 func waitWithDefaultGrace(ctx context.Context, identifier string, op string, gracePeriod time.Duration, defaultGrace time.Duration) error {
 	grace := gracePeriod
 	if grace <= 0 {
@@ -1,6 +1,9 @@
 package vps
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 // Pricing represents the pricing information
 // used for on-demand VPS resources.
@@ -8,6 +11,9 @@ type Pricing struct {
 	Disk     DiskPrices       `json:"disk"`
 	IPv4     int64            `json:"ipv4"`
 	Products map[string]int64 `json:"products"`
+
+	// Raw is the JSON the pricing was decoded from. See Extra.
+	Raw json.RawMessage `json:"-"`
 }
 
 // DiskPrices represents the pricing information
@@ -2,10 +2,13 @@ package vps
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 	"regexp"
 	"sort"
 	"strconv"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 )
 
 // Product represents an available VPS product.
@@ -17,6 +20,9 @@ type Product struct {
 	Family      string       `json:"family"`
 	Period      string       `json:"period"`
 	Specs       ProductSpecs `json:"specs"`
+
+	// Raw is the JSON the product was decoded from. See Extra.
+	Raw json.RawMessage `json:"-"`
 }
 
 // ProductSpecs represents the specifications of a Product.
@@ -66,7 +72,7 @@ func (s *Service) GetProducts(ctx context.Context, period ProductPeriod) (Produc
 	}
 
 	var products Products
-	if _, _, err := s.GetJSON(ctx, path, &products); err != nil {
+	if _, _, err := s.GetJSON(transport.Cacheable(ctx), path, &products); err != nil {
 		return nil, err
 	}
 
@@ -108,3 +114,38 @@ func (s *Service) ListProducts(ctx context.Context, period ProductPeriod) ([]Pro
 
 	return products, nil
 }
+
+// GetProduct retrieves the product with the given code.
+// Products are looked up in the API default period.
+// Returns ErrProductNotFound if there is no such product.
+func (s *Service) GetProduct(ctx context.Context, code string) (Product, error) {
+	products, err := s.GetProducts(ctx, "")
+	if err != nil {
+		return Product{}, err
+	}
+
+	if product, ok := products[code]; ok {
+		return product, nil
+	}
+	for _, product := range products {
+		if product.Code == code {
+			return product, nil
+		}
+	}
+
+	return Product{}, &ErrProductNotFound{Code: code}
+}
+
+// ChangeBillingPeriod changes the billing period of the VPS,
+// e.g. from on-demand to a fixed monthly or yearly term.
+// Returns ErrInvalidProductPeriod if the period is not valid.
+func (s *Service) ChangeBillingPeriod(ctx context.Context, identifier string, period ProductPeriod) (UpdateResponse, error) {
+	if period == "" {
+		return UpdateResponse{}, &ErrInvalidProductPeriod{Period: period}
+	}
+
+	req := NewUpdateRequest()
+	req.SetPeriod(period)
+
+	return s.Update(ctx, identifier, req)
+}
@@ -0,0 +1,87 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func productsMux(t *testing.T) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/products", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"VPSX4":{"name":"VPS 4","code":"VPSX4","family":"vps","specs":{"cores":1,"ram":4096}},
+			"VPSX16":{"name":"VPS 16","code":"VPSX16","family":"vps","specs":{"cores":4,"ram":16384}}
+		}`))
+	})
+	return mux
+}
+
+func TestGetProduct(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, productsMux(t))
+	defer srv.Close()
+
+	product, err := c.VPS().GetProduct(testContext(), "VPSX16")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if product.Specs.Cores != 4 {
+		t.Fatalf("cores=%d, want 4", product.Specs.Cores)
+	}
+}
+
+func TestGetProduct_NotFound(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, productsMux(t))
+	defer srv.Close()
+
+	_, err := c.VPS().GetProduct(testContext(), "VPSX99")
+	var notFound *vpsapi.ErrProductNotFound
+	if !errors.As(err, &notFound) || notFound.Code != "VPSX99" {
+		t.Fatalf("err=%v, want ErrProductNotFound", err)
+	}
+}
+
+func TestChangeBillingPeriod(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != http.MethodPatch || body["period"] != "year" {
+			t.Fatalf("method=%s body=%v", r.Method, body)
+		}
+		_, _ = w.Write([]byte(`{"message":"billing period updated"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	if _, err := c.VPS().ChangeBillingPeriod(testContext(), "box", vpsapi.ProductPeriodYear); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for _, period := range []vpsapi.ProductPeriod{"", vpsapi.ProductPeriodAll, "fortnight"} {
+		_, err := c.VPS().ChangeBillingPeriod(testContext(), "box", period)
+		var invalid *vpsapi.ErrInvalidProductPeriod
+		if !errors.As(err, &invalid) {
+			t.Fatalf("period %q: err=%v, want ErrInvalidProductPeriod", period, err)
+		}
+	}
+}
+
+func TestCreateAsync_InvalidPeriod(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, http.NewServeMux())
+	defer srv.Close()
+
+	_, err := c.VPS().CreateAsync(testContext(), "box", vpsapi.CreateRequest{Product: "VPSX4", Period: "weekly"})
+	var invalid *vpsapi.ErrInvalidProductPeriod
+	if !errors.As(err, &invalid) {
+		t.Fatalf("err=%v, want ErrInvalidProductPeriod", err)
+	}
+}
@@ -0,0 +1,35 @@
+package vps
+
+import "path"
+
+// DeleteOptions controls Delete.
+type DeleteOptions struct {
+	// Force deletes the VPS even if it matches Service.Protected.
+	Force bool
+}
+
+// IsProtected reports whether the identifier matches one of the
+// Service.Protected patterns, and returns the pattern it matched.
+func (s *Service) IsProtected(identifier string) (string, bool) {
+	for _, pattern := range s.Protected {
+		if pattern == identifier {
+			return pattern, true
+		}
+		if ok, err := path.Match(pattern, identifier); err == nil && ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// checkProtected returns ErrProtected if the identifier is protected
+// and opts does not force the deletion.
+func (s *Service) checkProtected(identifier string, opts DeleteOptions) error {
+	if opts.Force {
+		return nil
+	}
+	if pattern, ok := s.IsProtected(identifier); ok {
+		return &ErrProtected{Identifier: identifier, Pattern: pattern}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package vps_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestDelete_Protected(t *testing.T) {
+	t.Parallel()
+	deletes := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/prod-web", func(w http.ResponseWriter, r *http.Request) {
+		deletes++
+		w.WriteHeader(http.StatusOK)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	service := c.VPS()
+	service.Protected = []string{"customer-db", "prod-*"}
+
+	err := service.Delete(testContext(), "prod-web")
+	var protected *vpsapi.ErrProtected
+	if !errors.As(err, &protected) || protected.Pattern != "prod-*" {
+		t.Fatalf("err=%v, want ErrProtected", err)
+	}
+	if deletes != 0 {
+		t.Fatalf("deletes=%d, want 0", deletes)
+	}
+
+	if err := service.Delete(testContext(), "prod-web", vpsapi.DeleteOptions{Force: true}); err != nil {
+		t.Fatalf("forced delete err: %v", err)
+	}
+	if deletes != 1 {
+		t.Fatalf("deletes=%d, want 1", deletes)
+	}
+
+	if _, ok := service.IsProtected("customer-db"); !ok {
+		t.Fatalf("customer-db should be protected")
+	}
+	if _, ok := service.IsProtected("staging-web"); ok {
+		t.Fatalf("staging-web should not be protected")
+	}
+}
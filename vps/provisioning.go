@@ -0,0 +1,247 @@
+package vps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
+
+// DefaultCreateTimeout is the default time to wait for a VPS to be provisioned.
+const DefaultCreateTimeout = 5 * time.Minute
+
+// ProvisioningJob tracks a VPS being provisioned by CreateAsync.
+type ProvisioningJob struct {
+	// Identifier is the identifier of the VPS being provisioned.
+	Identifier string
+	// PollURL is the URL polled for the provisioning status.
+	PollURL string
+
+	service *Service
+}
+
+// CreateOptions controls how Create and ProvisioningJob.Wait
+// wait for a VPS to be provisioned, and how Create places it.
+type CreateOptions struct {
+	// Timeout bounds the wait for provisioning.
+	// If Timeout <= 0, DefaultCreateTimeout is used.
+	Timeout time.Duration
+	// PollInterval is the wait between polls.
+	// If PollInterval <= 0, the client's PollInterval is used.
+	PollInterval time.Duration
+	// OnProgress, if set, is called with the status of each poll,
+	// including polls answered with 202 Accepted while the job is queued.
+	OnProgress func(ProvisioningStatus)
+	// PickHost, if set, makes Create choose a private cloud host
+	// with this strategy when the request has no HostServer.
+	// It is not used by ProvisioningJob.Wait.
+	PickHost HostStrategy
+	// ResolveUserData makes Create look up a user data name in the
+	// request and send its ID instead, rather than leaving name
+	// resolution to the API. It is not used by ProvisioningJob.Wait.
+	ResolveUserData bool
+}
+
+// ProvisioningStatus represents a single poll of a provisioning job.
+type ProvisioningStatus struct {
+	// Status is the status reported by the API, e.g. "running".
+	Status string
+	// Done reports whether the VPS has finished provisioning.
+	Done bool
+	// Data is the decoded poll response, if there was one.
+	Data map[string]any
+	// StatusCode is the HTTP status of the poll response.
+	StatusCode int
+	// Time is when the poll response was received.
+	Time time.Time
+}
+
+// provisioningStatus converts a poll response into a ProvisioningStatus.
+// A 303 means the VPS is ready; a 202 without a status in its body
+// means the job is still queued.
+func provisioningStatus(event transport.PollEvent) ProvisioningStatus {
+	status, _ := event.Data["status"].(string)
+	switch event.StatusCode {
+	case http.StatusSeeOther:
+		status = string(StatusRunning)
+	case http.StatusAccepted:
+		if status == "" {
+			status = string(StatusProvisioning)
+		}
+	}
+	return ProvisioningStatus{
+		Status:     status,
+		Done:       status == string(StatusRunning),
+		Data:       event.Data,
+		StatusCode: event.StatusCode,
+		Time:       event.Time,
+	}
+}
+
+// CreateAsync requests a new VPS with the given identifier and request
+// parameters and returns once the API has accepted the request.
+// Use the returned job to wait for, check or cancel provisioning.
+//
+// Returns ErrIdentifierConflict if the identifier is already in use, and
+// ErrPaymentRequired or ErrQuotaExceeded if the account cannot take it.
+// See Service.CreateRetry for retrying failed provisioning requests.
+func (s *Service) CreateAsync(ctx context.Context, identifier string, server CreateRequest) (*ProvisioningJob, error) {
+	if strings.TrimSpace(identifier) == "" {
+		return nil, ErrEmptyIdentifier
+	}
+	if err := server.validateEnums(); err != nil {
+		return nil, err
+	}
+
+	requestURL := fmt.Sprintf("/vps/servers/%s", identifier)
+
+	res, body, err := s.PostCreate(ctx, requestURL, server, s.CreateRetry)
+	if errors.Is(err, transport.ErrExists) {
+		return nil, &ErrIdentifierConflict{Identifier: identifier}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusConflict {
+		return nil, &ErrIdentifierConflict{Identifier: identifier}
+	}
+
+	if res.StatusCode != http.StatusAccepted {
+		return nil, transport.AccountError(s.NewAPIError(res, body))
+	}
+
+	pollURL := res.Header.Get("Location")
+	if pollURL == "" {
+		return nil, fmt.Errorf("missing header location for polling")
+	}
+
+	return &ProvisioningJob{Identifier: identifier, PollURL: pollURL, service: s}, nil
+}
+
+// Wait blocks until the VPS is running or the timeout is reached,
+// then returns the provisioned server.
+// On timeout it returns ErrWaitTimeout, and if provisioning
+// fails it returns ProvisioningError.
+// Only the first CreateOptions is used.
+func (j *ProvisioningJob) Wait(ctx context.Context, opts ...CreateOptions) (Server, error) {
+	var o CreateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = DefaultCreateTimeout
+	}
+
+	isVPSReady := func(data map[string]any, identifier string) (string, bool) {
+		status, _ := data["status"].(string)
+		if status == string(StatusRunning) {
+			return fmt.Sprintf("/vps/servers/%s", identifier), true
+		}
+		return "", false
+	}
+
+	var last string
+	poll := transport.PollOptions{Interval: o.PollInterval, Observer: func(event transport.PollEvent) {
+		status := provisioningStatus(event)
+		last = status.Status
+		if o.OnProgress != nil {
+			o.OnProgress(status)
+		}
+	}}
+
+	serverURL, err := j.service.PollProvisioning(ctx, j.PollURL, timeout, j.Identifier, poll, isVPSReady)
+	if errors.Is(err, transport.ErrPollTimeout) {
+		return Server{}, &ErrWaitTimeout{Identifier: j.Identifier, Want: string(StatusRunning), Last: last}
+	}
+	var failed *transport.ErrPollFailed
+	if errors.As(err, &failed) {
+		return Server{}, j.provisioningError([]byte(failed.Body), err)
+	}
+	if err != nil {
+		return Server{}, err
+	}
+
+	var created Server
+	if _, _, err := j.service.GetJSON(ctx, serverURL, &created, http.StatusOK); err != nil {
+		return Server{}, fmt.Errorf("failed to fetch server info: %w", err)
+	}
+
+	return created, nil
+}
+
+// Status polls the job once and reports the provisioning status.
+// If the job has failed it returns ProvisioningError.
+func (j *ProvisioningJob) Status(ctx context.Context) (ProvisioningStatus, error) {
+	res, err := j.service.BaseService.Get(ctx, j.PollURL)
+	if err != nil {
+		return ProvisioningStatus{}, err
+	}
+
+	body, err := j.service.Body(res)
+	if err != nil {
+		return ProvisioningStatus{}, err
+	}
+
+	event := transport.PollEvent{Time: time.Now(), StatusCode: res.StatusCode}
+
+	switch res.StatusCode {
+	case http.StatusSeeOther, http.StatusAccepted:
+		_ = json.Unmarshal(body, &event.Data)
+		return provisioningStatus(event), nil
+	case http.StatusOK:
+		if err := json.Unmarshal(body, &event.Data); err != nil {
+			return ProvisioningStatus{}, err
+		}
+		return provisioningStatus(event), nil
+	case http.StatusInternalServerError:
+		return ProvisioningStatus{}, j.provisioningError(body, &transport.ErrPollFailed{Body: string(body)})
+	default:
+		return ProvisioningStatus{}, j.service.NewAPIError(res, body)
+	}
+}
+
+// provisioningError builds the ProvisioningError for a failed poll
+// response. If the body gives no reason the whole body is used.
+func (j *ProvisioningJob) provisioningError(body []byte, err error) *ProvisioningError {
+	var parsed struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &parsed)
+	reason := parsed.Error
+	if reason == "" {
+		reason = parsed.Message
+	}
+	if reason == "" {
+		reason = strings.TrimSpace(string(body))
+	}
+
+	return &ProvisioningError{Identifier: j.Identifier, Reason: reason, Err: err}
+}
+
+// Cancel aborts provisioning server-side.
+//
+// It cancels the queued job by sending DELETE to the poll URL.
+// If the API does not support that, or the job has already finished,
+// it returns ErrCancelUnsupported and never deletes the VPS itself.
+func (j *ProvisioningJob) Cancel(ctx context.Context) error {
+	_, _, err := j.service.DoJSON(ctx, http.MethodDelete, j.PollURL, nil, nil,
+		http.StatusOK, http.StatusAccepted, http.StatusNoContent)
+
+	var apiErr *transport.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return fmt.Errorf("%w: %w", ErrCancelUnsupported, err)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,389 @@
+package vps_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func provisioningMux(t *testing.T, id string, polls *int) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/"+id, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/queue/vps/"+id)
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"identifier":"` + id + `","status":"running"}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	})
+	mux.HandleFunc("/queue/vps/"+id, func(w http.ResponseWriter, r *http.Request) {
+		*polls++
+		if *polls < 2 {
+			_, _ = w.Write([]byte(`{"status":"installing"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"running"}`))
+	})
+	return mux
+}
+
+func TestCreate_PollsUntilRunning(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	c, srv := newTestClient(t, provisioningMux(t, "new", &polls))
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	server, err := c.VPS().Create(testContext(), "new", vpsapi.CreateRequest{Product: "VPSX16"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Identifier != "new" || server.Status != "running" {
+		t.Fatalf("server=%+v", server)
+	}
+	if polls != 2 {
+		t.Fatalf("polls=%d, want 2", polls)
+	}
+}
+
+func TestCreateAsync_StatusAndWait(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	c, srv := newTestClient(t, provisioningMux(t, "async", &polls))
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	job, err := c.VPS().CreateAsync(testContext(), "async", vpsapi.CreateRequest{Product: "VPSX16"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if job.Identifier != "async" || job.PollURL != "/queue/vps/async" {
+		t.Fatalf("job=%+v", job)
+	}
+
+	status, err := job.Status(testContext())
+	if err != nil {
+		t.Fatalf("status err: %v", err)
+	}
+	if status.Status != "installing" || status.Done {
+		t.Fatalf("status=%+v", status)
+	}
+
+	server, err := job.Wait(testContext())
+	if err != nil {
+		t.Fatalf("wait err: %v", err)
+	}
+	if server.Identifier != "async" {
+		t.Fatalf("server=%+v", server)
+	}
+}
+
+func TestCreateAsync_Failed(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/queue/vps/broken")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/queue/vps/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":"no capacity in zone"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.PollInterval = time.Millisecond
+
+	job, err := c.VPS().CreateAsync(testContext(), "broken", vpsapi.CreateRequest{Product: "VPSX4"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	var failed *vpsapi.ProvisioningError
+	if _, err := job.Status(testContext()); !errors.As(err, &failed) {
+		t.Fatalf("status err=%v, want ProvisioningError", err)
+	}
+	if failed.Identifier != "broken" || failed.Reason != "no capacity in zone" {
+		t.Fatalf("status err=%+v", failed)
+	}
+
+	failed = nil
+	if _, err := job.Wait(testContext()); !errors.As(err, &failed) {
+		t.Fatalf("wait err=%v, want ProvisioningError", err)
+	}
+	if failed.Reason != "no capacity in zone" {
+		t.Fatalf("wait err=%+v", failed)
+	}
+}
+
+func TestCreateAsync_Cancel(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	c, srv := newTestClient(t, provisioningMux(t, "cancel", &polls))
+	defer srv.Close()
+
+	job, err := c.VPS().CreateAsync(testContext(), "cancel", vpsapi.CreateRequest{Product: "VPSX16"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if err := job.Cancel(testContext()); err != nil {
+		t.Fatalf("cancel err: %v", err)
+	}
+}
+
+func TestProvisioningJob_Cancel(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		queueStatus int
+		want        string
+		wantErr     error
+	}{
+		{name: "cancels queued job", queueStatus: http.StatusNoContent, want: "DELETE /queue/vps/job"},
+		{name: "unsupported", queueStatus: http.StatusMethodNotAllowed, want: "DELETE /queue/vps/job", wantErr: vpsapi.ErrCancelUnsupported},
+		{name: "already finished", queueStatus: http.StatusNotFound, want: "DELETE /queue/vps/job", wantErr: vpsapi.ErrCancelUnsupported},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []string
+			mux := http.NewServeMux()
+			mux.HandleFunc("/queue/vps/job", func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				w.WriteHeader(tc.queueStatus)
+			})
+			mux.HandleFunc("/vps/servers/job", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.Header().Set("Location", "/queue/vps/job")
+					w.WriteHeader(http.StatusAccepted)
+					return
+				}
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				w.WriteHeader(http.StatusOK)
+			})
+			c, srv := newTestClient(t, mux)
+			defer srv.Close()
+
+			job, err := c.VPS().CreateAsync(testContext(), "job", vpsapi.CreateRequest{Product: "VPSX16"})
+			if err != nil {
+				t.Fatalf("err: %v", err)
+			}
+			if err := job.Cancel(testContext()); !errors.Is(err, tc.wantErr) {
+				t.Fatalf("err=%v, want %v", err, tc.wantErr)
+			}
+			if got := strings.Join(calls, ","); got != tc.want {
+				t.Fatalf("calls=%s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProvisioningJob_CancelAfterRunning(t *testing.T) {
+	t.Parallel()
+	var deletes []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/queue/vps/done", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes = append(deletes, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Location", "/vps/servers/done")
+		w.WriteHeader(http.StatusSeeOther)
+	})
+	mux.HandleFunc("/vps/servers/done", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/queue/vps/done")
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		default:
+			_, _ = w.Write([]byte(`{"identifier":"done","status":"running"}`))
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	job, err := c.VPS().CreateAsync(testContext(), "done", vpsapi.CreateRequest{Product: "VPSX16"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := job.Wait(testContext(), vpsapi.CreateOptions{PollInterval: time.Millisecond}); err != nil {
+		t.Fatalf("wait err: %v", err)
+	}
+
+	if err := job.Cancel(testContext()); !errors.Is(err, vpsapi.ErrCancelUnsupported) {
+		t.Fatalf("err=%v, want ErrCancelUnsupported", err)
+	}
+	if len(deletes) != 1 || deletes[0] != "/queue/vps/done" {
+		t.Fatalf("deletes=%v, want only the queued job", deletes)
+	}
+}
+
+func TestCreateAsync_EmptyIdentifier(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, http.NewServeMux())
+	defer srv.Close()
+	if _, err := c.VPS().CreateAsync(testContext(), " ", vpsapi.CreateRequest{}); err != vpsapi.ErrEmptyIdentifier {
+		t.Fatalf("err=%v, want ErrEmptyIdentifier", err)
+	}
+}
+
+func TestCreate_WithOptions(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	c, srv := newTestClient(t, provisioningMux(t, "opts", &polls))
+	defer srv.Close()
+	c.PollInterval = time.Hour
+
+	var seen []string
+	server, err := c.VPS().Create(testContext(), "opts", vpsapi.CreateRequest{Product: "VPSX16"}, vpsapi.CreateOptions{
+		Timeout:      time.Second,
+		PollInterval: time.Millisecond,
+		OnProgress: func(status vpsapi.ProvisioningStatus) {
+			seen = append(seen, status.Status)
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Identifier != "opts" {
+		t.Fatalf("server=%+v", server)
+	}
+	if len(seen) != 2 || seen[0] != "installing" || seen[1] != "running" {
+		t.Fatalf("progress=%v", seen)
+	}
+}
+
+func TestCreateAsync_AccountErrors(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/broke", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusPaymentRequired)
+		_, _ = w.Write([]byte(`{"error":"Insufficient account credit"}`))
+	})
+	mux.HandleFunc("/vps/servers/limited", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"VPS limit reached"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.VPS().CreateAsync(testContext(), "broke", vpsapi.CreateRequest{Product: "VPSX4"})
+	var funds *vpsapi.ErrInsufficientFunds
+	if !errors.As(err, &funds) || funds.Message != "Insufficient account credit" {
+		t.Fatalf("err=%v, want ErrInsufficientFunds", err)
+	}
+	var apiErr *mythicbeasts.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusPaymentRequired {
+		t.Fatalf("err=%v, want wrapped APIError", err)
+	}
+
+	_, err = c.VPS().CreateAsync(testContext(), "limited", vpsapi.CreateRequest{Product: "VPSX4"})
+	var quota *vpsapi.ErrQuotaExceeded
+	if !errors.As(err, &quota) || quota.Message != "VPS limit reached" {
+		t.Fatalf("err=%v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestCreate_OnProgressIncludesQueuedPolls(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/queued", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Header().Set("Location", "/queue/vps/queued")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		_, _ = w.Write([]byte(`{"identifier":"queued","status":"running"}`))
+	})
+	mux.HandleFunc("/queue/vps/queued", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch polls {
+		case 1:
+			w.WriteHeader(http.StatusAccepted)
+		case 2:
+			_, _ = w.Write([]byte(`{"status":"installing","progress":40}`))
+		default:
+			_, _ = w.Write([]byte(`{"status":"running"}`))
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	start := time.Now()
+	var seen []vpsapi.ProvisioningStatus
+	_, err := c.VPS().Create(testContext(), "queued", vpsapi.CreateRequest{Product: "VPSX16"}, vpsapi.CreateOptions{
+		PollInterval: time.Millisecond,
+		OnProgress: func(status vpsapi.ProvisioningStatus) {
+			seen = append(seen, status)
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(seen) != 3 {
+		t.Fatalf("progress=%+v, want 3 polls", seen)
+	}
+	if seen[0].StatusCode != http.StatusAccepted || seen[0].Status != "provisioning" {
+		t.Fatalf("first=%+v, want queued poll", seen[0])
+	}
+	if seen[1].Data["progress"] != float64(40) {
+		t.Fatalf("second=%+v, want progress payload", seen[1])
+	}
+	if !seen[2].Done {
+		t.Fatalf("last=%+v, want done", seen[2])
+	}
+	for i, status := range seen {
+		if status.Time.Before(start) || (i > 0 && status.Time.Before(seen[i-1].Time)) {
+			t.Fatalf("poll %d time=%v out of order", i, status.Time)
+		}
+	}
+}
+
+func TestCreate_Timeout(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/stuck", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/queue/vps/stuck")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/queue/vps/stuck", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"installing"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.VPS().Create(testContext(), "stuck", vpsapi.CreateRequest{Product: "VPSX4"}, vpsapi.CreateOptions{
+		Timeout:      10 * time.Millisecond,
+		PollInterval: time.Millisecond,
+	})
+	var timeout *vpsapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+	if timeout.Identifier != "stuck" || timeout.Want != "running" || timeout.Last != "installing" {
+		t.Fatalf("timeout=%+v", timeout)
+	}
+}
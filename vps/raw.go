@@ -0,0 +1,69 @@
+package vps
+
+import "encoding/json"
+
+// UnmarshalJSON decodes the server and keeps the raw JSON in Raw.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type plain Server
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	s.Raw = cloneRaw(data)
+	return nil
+}
+
+// Extra returns the raw JSON of a top-level field of the server as
+// sent by the API, including fields this package does not decode.
+func (s Server) Extra(key string) (json.RawMessage, bool) {
+	return extraField(s.Raw, key)
+}
+
+// UnmarshalJSON decodes the product and keeps the raw JSON in Raw.
+func (p *Product) UnmarshalJSON(data []byte) error {
+	type plain Product
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	p.Raw = cloneRaw(data)
+	return nil
+}
+
+// Extra returns the raw JSON of a top-level field of the product as
+// sent by the API, including fields this package does not decode.
+func (p Product) Extra(key string) (json.RawMessage, bool) {
+	return extraField(p.Raw, key)
+}
+
+// UnmarshalJSON decodes the pricing and keeps the raw JSON in Raw.
+func (p *Pricing) UnmarshalJSON(data []byte) error {
+	type plain Pricing
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+	p.Raw = cloneRaw(data)
+	return nil
+}
+
+// Extra returns the raw JSON of a top-level field of the pricing as
+// sent by the API, including fields this package does not decode.
+func (p Pricing) Extra(key string) (json.RawMessage, bool) {
+	return extraField(p.Raw, key)
+}
+
+// cloneRaw copies data, which the decoder may reuse.
+func cloneRaw(data []byte) json.RawMessage {
+	return append(json.RawMessage(nil), data...)
+}
+
+// extraField returns the raw value of key in the JSON object raw.
+func extraField(raw json.RawMessage, key string) (json.RawMessage, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, false
+	}
+	value, ok := fields[key]
+	return value, ok
+}
@@ -0,0 +1,60 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestServer_Extra(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/web", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"web","product":"VPSX4","secure_boot":{"enabled":true}}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	server, err := c.VPS().Get(testContext(), "web")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Product != "VPSX4" {
+		t.Fatalf("server=%+v", server)
+	}
+
+	raw, ok := server.Extra("secure_boot")
+	if !ok {
+		t.Fatalf("secure_boot missing from %s", server.Raw)
+	}
+	var secureBoot struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.Unmarshal(raw, &secureBoot); err != nil || !secureBoot.Enabled {
+		t.Fatalf("secure_boot=%s err=%v", raw, err)
+	}
+	if _, ok := server.Extra("missing"); ok {
+		t.Fatalf("Extra(missing) ok=true, want false")
+	}
+}
+
+func TestProductAndPricing_Extra(t *testing.T) {
+	t.Parallel()
+	var product vpsapi.Product
+	if err := json.Unmarshal([]byte(`{"code":"VPSX4","gpu":"none"}`), &product); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if raw, ok := product.Extra("gpu"); !ok || string(raw) != `"none"` {
+		t.Fatalf("gpu=%s ok=%v", raw, ok)
+	}
+
+	var pricing vpsapi.Pricing
+	if err := json.Unmarshal([]byte(`{"ipv4":100,"ipv6_block":50}`), &pricing); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if raw, ok := pricing.Extra("ipv6_block"); pricing.IPv4 != 100 || !ok || string(raw) != "50" {
+		t.Fatalf("pricing=%+v ipv6_block=%s ok=%v", pricing, raw, ok)
+	}
+}
@@ -0,0 +1,127 @@
+package vps
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRebootProbeDelay is the default wait before the first probe
+	// in RebootAndWaitReachable, giving the ACPI reboot time to start.
+	DefaultRebootProbeDelay = 5 * time.Second
+
+	// sshBannerTimeout bounds the wait for an SSH banner after connecting.
+	sshBannerTimeout = 10 * time.Second
+)
+
+// Probe reports whether a VPS is reachable, returning nil when it is.
+type Probe func(ctx context.Context, server Server) error
+
+// DialSSHProxy is a Probe that connects through the SSH proxy of the
+// server and reads the SSH banner of the VPS. The proxy itself accepts
+// connections while the VPS is down, so the banner is what shows the
+// VPS is up.
+func DialSSHProxy(ctx context.Context, server Server) error {
+	if server.SSHProxy.Hostname == "" || server.SSHProxy.Port == 0 {
+		return errors.New("server has no ssh proxy")
+	}
+
+	addr := net.JoinHostPort(server.SSHProxy.Hostname, strconv.FormatInt(server.SSHProxy.Port, 10))
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := conn.SetReadDeadline(time.Now().Add(sshBannerTimeout)); err != nil {
+		return err
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("reading ssh banner: %w", err)
+	}
+	if !strings.HasPrefix(line, "SSH-") {
+		return fmt.Errorf("unexpected ssh banner %q", strings.TrimSpace(line))
+	}
+
+	return nil
+}
+
+// RebootOptions controls RebootAndWaitReachable.
+type RebootOptions struct {
+	// Wait controls the interval between probes and the overall timeout.
+	Wait WaitOptions
+	// Delay is the wait before the first probe, giving the VPS time
+	// to start going down.
+	// If Delay <= 0, DefaultRebootProbeDelay is used.
+	Delay time.Duration
+	// Probe checks whether the VPS is reachable.
+	// If Probe is nil, DialSSHProxy is used.
+	Probe Probe
+}
+
+// RebootAndWaitReachable initiates an ACPI reboot and then probes the VPS
+// until it has been seen unreachable and is reachable again, the timeout
+// is reached or the context ends. Requiring the VPS to be seen down means
+// it is not reported as back before the reboot has happened, so
+// opts.Wait.Interval must be short enough to see it down.
+// Returns ErrWaitTimeout if the VPS is not seen to go down and come back in time.
+func (s *Service) RebootAndWaitReachable(ctx context.Context, identifier string, opts RebootOptions) (RebootResponse, error) {
+	server, err := s.Get(ctx, identifier)
+	if err != nil {
+		return RebootResponse{}, err
+	}
+
+	resp, err := s.Reboot(ctx, identifier)
+	if err != nil {
+		return RebootResponse{}, err
+	}
+
+	delay := opts.Delay
+	if delay <= 0 {
+		delay = DefaultRebootProbeDelay
+	}
+	timer := time.NewTimer(delay)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return RebootResponse{}, ctx.Err()
+	case <-timer.C:
+	}
+
+	probe := opts.Probe
+	if probe == nil {
+		probe = DialSSHProxy
+	}
+
+	var (
+		lastErr error
+		down    bool
+	)
+	err = opts.Wait.poll(ctx, func(ctx context.Context) (bool, error) {
+		lastErr = probe(ctx, server)
+		if lastErr != nil {
+			down = true
+		}
+		return down && lastErr == nil, nil
+	})
+	if errors.Is(err, errWaitTimeout) {
+		last := "not seen down"
+		if lastErr != nil {
+			last = lastErr.Error()
+		}
+		return RebootResponse{}, &ErrWaitTimeout{Identifier: identifier, Want: "reachable after reboot", Last: last}
+	}
+	if err != nil {
+		return RebootResponse{}, err
+	}
+
+	return resp, nil
+}
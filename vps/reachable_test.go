@@ -0,0 +1,127 @@
+package vps_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func rebootMux(t *testing.T, sshHost string, sshPort int) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"identifier":"box","status":"running","ssh_proxy":{"hostname":%q,"port":%d}}`, sshHost, sshPort)
+	})
+	mux.HandleFunc("/vps/servers/box/reboot", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("want POST")
+		}
+		_, _ = w.Write([]byte(`{"message":"rebooting"}`))
+	})
+	return mux
+}
+
+func TestRebootAndWaitReachable_DialsSSHProxy(t *testing.T) {
+	t.Parallel()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for accepted := 0; ; accepted++ {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			// The proxy accepts the first connection while the VPS
+			// is down and closes it without a banner.
+			if accepted > 0 {
+				_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.2\r\n"))
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	c, srv := newTestClient(t, rebootMux(t, "127.0.0.1", port))
+	defer srv.Close()
+
+	resp, err := c.VPS().RebootAndWaitReachable(testContext(), "box", vpsapi.RebootOptions{
+		Wait:  vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+		Delay: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if resp.Message != "rebooting" {
+		t.Fatalf("resp=%+v", resp)
+	}
+}
+
+func TestRebootAndWaitReachable_CustomProbe(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, rebootMux(t, "proxy.example", 22))
+	defer srv.Close()
+
+	probes := 0
+	_, err := c.VPS().RebootAndWaitReachable(testContext(), "box", vpsapi.RebootOptions{
+		Wait:  vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+		Delay: time.Millisecond,
+		Probe: func(ctx context.Context, server vpsapi.Server) error {
+			probes++
+			if probes < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if probes != 3 {
+		t.Fatalf("probes=%d, want 3", probes)
+	}
+}
+
+func TestRebootAndWaitReachable_Timeout(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, rebootMux(t, "proxy.example", 22))
+	defer srv.Close()
+
+	_, err := c.VPS().RebootAndWaitReachable(testContext(), "box", vpsapi.RebootOptions{
+		Wait:  vpsapi.WaitOptions{Interval: 5 * time.Millisecond, Timeout: 20 * time.Millisecond},
+		Delay: time.Millisecond,
+		Probe: func(ctx context.Context, server vpsapi.Server) error {
+			return errors.New("connection refused")
+		},
+	})
+	var timeout *vpsapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Last != "connection refused" {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+}
+
+func TestRebootAndWaitReachable_RequiresDown(t *testing.T) {
+	t.Parallel()
+	c, srv := newTestClient(t, rebootMux(t, "proxy.example", 22))
+	defer srv.Close()
+
+	_, err := c.VPS().RebootAndWaitReachable(testContext(), "box", vpsapi.RebootOptions{
+		Wait:  vpsapi.WaitOptions{Interval: 5 * time.Millisecond, Timeout: 20 * time.Millisecond},
+		Delay: time.Millisecond,
+		Probe: func(ctx context.Context, server vpsapi.Server) error {
+			return nil
+		},
+	})
+	var timeout *vpsapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Last != "not seen down" {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+}
@@ -0,0 +1,97 @@
+package vps
+
+import "context"
+
+// ResizeTarget represents the wanted size of a VPS.
+// Empty or nil fields are left unchanged.
+type ResizeTarget struct {
+	Product string
+	Specs   UpdateSpecs
+}
+
+// ResizeOptions controls Resize.
+type ResizeOptions struct {
+	// Wait controls polling while waiting for power state
+	// changes and for the new specs to be reported.
+	Wait WaitOptions
+	// Shutdown requests an ACPI shutdown instead of a hard power-off
+	// when the VPS has to be power cycled.
+	Shutdown bool
+	// Capabilities decides which fields need a power cycle to apply.
+	// If Capabilities is nil, DefaultUpdateCapabilities is used.
+	Capabilities *UpdateCapabilities
+}
+
+// resizeUpdate builds the minimal update needed to take server to target.
+func resizeUpdate(server Server, target ResizeTarget) (req UpdateRequest, changed bool) {
+	req = NewUpdateRequest()
+
+	if target.Product != "" && target.Product != server.Product {
+		req.SetProduct(target.Product)
+		changed = true
+	}
+
+	specs := NewUpdateSpecs()
+	specsChanged := false
+	if v := target.Specs.DiskSize; v != nil && *v != server.Specs.DiskSize {
+		specs.SetDiskSize(*v)
+		specsChanged = true
+	}
+	if v := target.Specs.ExtraCores; v != nil && *v != server.Specs.ExtraCores {
+		specs.SetExtraCores(*v)
+		specsChanged = true
+	}
+	if v := target.Specs.ExtraRAM; v != nil && *v != server.Specs.ExtraRAM {
+		specs.SetExtraRAM(*v)
+		specsChanged = true
+	}
+	if specsChanged {
+		req.SetSpecs(specs)
+		changed = true
+	}
+
+	return req, changed
+}
+
+// resized reports whether server matches target.
+func resized(server Server, target ResizeTarget) bool {
+	_, changed := resizeUpdate(server, target)
+	return !changed
+}
+
+// Resize changes the product and specs of the VPS to target.
+//
+// It fetches the VPS and only sends the fields that differ.
+// A running VPS is power cycled if opts.Capabilities reports that
+// a changed field only takes effect after a restart; by default that
+// is the product, cores and RAM but not the disk size.
+// It then waits for the VPS to report the new specs and returns it.
+// If nothing differs no update is sent.
+func (s *Service) Resize(ctx context.Context, identifier string, target ResizeTarget, opts ResizeOptions) (Server, error) {
+	server, err := s.Get(ctx, identifier)
+	if err != nil {
+		return Server{}, err
+	}
+
+	req, changed := resizeUpdate(server, target)
+	if !changed {
+		return server, nil
+	}
+
+	restart := capabilitiesOrDefault(opts.Capabilities).RequiresPowerCycle(req)
+
+	if _, err := s.Update(ctx, identifier, req); err != nil {
+		return Server{}, err
+	}
+
+	if restart && server.PowerState() == PowerStateOn {
+		cycle := PowerCycleOptions{Wait: opts.Wait, Shutdown: opts.Shutdown, WaitForRunning: true}
+		if _, err := s.PowerCycle(ctx, identifier, cycle); err != nil {
+			return Server{}, err
+		}
+	}
+
+	return s.waitFor(ctx, identifier, "resized", opts.Wait, func(server Server) bool {
+		return resized(server, target)
+	})
+}
@@ -0,0 +1,159 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+type fakeResize struct {
+	status  string
+	server  vpsapi.Server
+	calls   []string
+	patches []map[string]any
+}
+
+func (f *fakeResize) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box/power", func(w http.ResponseWriter, r *http.Request) {
+		var req vpsapi.PowerRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.calls = append(f.calls, string(req.Power))
+		if req.Power == vpsapi.PowerActionOn {
+			f.server.Status = "running"
+		} else {
+			f.server.Status = "powered off"
+		}
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(f.server)
+		case http.MethodPatch:
+			var body struct {
+				Product *string             `json:"product"`
+				Specs   *vpsapi.UpdateSpecs `json:"specs"`
+			}
+			raw := map[string]any{}
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &body)
+			_ = json.Unmarshal(data, &raw)
+			f.calls = append(f.calls, "patch")
+			f.patches = append(f.patches, raw)
+			if body.Product != nil {
+				f.server.Product = *body.Product
+			}
+			if body.Specs != nil && body.Specs.DiskSize != nil {
+				f.server.Specs.DiskSize = *body.Specs.DiskSize
+			}
+			if body.Specs != nil && body.Specs.ExtraRAM != nil {
+				f.server.Specs.ExtraRAM = *body.Specs.ExtraRAM
+			}
+			_, _ = w.Write([]byte(`{"message":"updated"}`))
+		}
+	})
+	return mux
+}
+
+func TestResize_PowerCyclesForRAM(t *testing.T) {
+	t.Parallel()
+	f := &fakeResize{server: vpsapi.Server{
+		Identifier: "box",
+		Status:     "running",
+		Product:    "VPSX4",
+		Specs:      vpsapi.ServerSpecs{DiskSize: 10240},
+	}}
+	c, srv := newTestClient(t, f.mux())
+	defer srv.Close()
+
+	target := vpsapi.ResizeTarget{Product: "VPSX4"}
+	target.Specs.SetDiskSize(10240)
+	target.Specs.SetExtraRAM(2048)
+
+	server, err := c.VPS().Resize(testContext(), "box", target, vpsapi.ResizeOptions{
+		Wait: vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Specs.ExtraRAM != 2048 {
+		t.Fatalf("extra_ram=%d, want 2048", server.Specs.ExtraRAM)
+	}
+
+	want := []string{"patch", "power-off", "power-on"}
+	if fmt.Sprint(f.calls) != fmt.Sprint(want) {
+		t.Fatalf("calls=%v, want %v", f.calls, want)
+	}
+	if _, ok := f.patches[0]["product"]; ok {
+		t.Fatalf("unchanged product sent: %v", f.patches[0])
+	}
+	specs, _ := f.patches[0]["specs"].(map[string]any)
+	if _, ok := specs["disk_size"]; ok || specs["extra_ram"] != float64(2048) {
+		t.Fatalf("specs=%v, want only extra_ram", specs)
+	}
+}
+
+func TestResize_DiskOnlyDoesNotPowerCycle(t *testing.T) {
+	t.Parallel()
+	f := &fakeResize{server: vpsapi.Server{
+		Identifier: "box",
+		Status:     "running",
+		Specs:      vpsapi.ServerSpecs{DiskSize: 10240},
+	}}
+	c, srv := newTestClient(t, f.mux())
+	defer srv.Close()
+
+	target := vpsapi.ResizeTarget{}
+	target.Specs.SetDiskSize(20480)
+
+	if _, err := c.VPS().Resize(testContext(), "box", target, vpsapi.ResizeOptions{
+		Wait: vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+	}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if fmt.Sprint(f.calls) != "[patch]" {
+		t.Fatalf("calls=%v, want [patch]", f.calls)
+	}
+}
+
+func TestResize_Capabilities(t *testing.T) {
+	t.Parallel()
+	f := &fakeResize{server: vpsapi.Server{Identifier: "box", Status: "running"}}
+	c, srv := newTestClient(t, f.mux())
+	defer srv.Close()
+
+	target := vpsapi.ResizeTarget{}
+	target.Specs.SetExtraRAM(2048)
+	caps := vpsapi.DefaultUpdateCapabilities()
+	caps.Hot["extra_ram"] = true
+
+	if _, err := c.VPS().Resize(testContext(), "box", target, vpsapi.ResizeOptions{
+		Wait:         vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+		Capabilities: &caps,
+	}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if fmt.Sprint(f.calls) != "[patch]" {
+		t.Fatalf("calls=%v, want [patch]", f.calls)
+	}
+}
+
+func TestResize_NoChange(t *testing.T) {
+	t.Parallel()
+	f := &fakeResize{server: vpsapi.Server{Identifier: "box", Status: "running", Product: "VPSX4"}}
+	c, srv := newTestClient(t, f.mux())
+	defer srv.Close()
+
+	if _, err := c.VPS().Resize(testContext(), "box", vpsapi.ResizeTarget{Product: "VPSX4"}, vpsapi.ResizeOptions{}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(f.calls) != 0 {
+		t.Fatalf("calls=%v, want none", f.calls)
+	}
+}
@@ -0,0 +1,79 @@
+package vps
+
+import "errors"
+
+// ErrNoMatchingProduct is returned by SelectProduct when
+// no product meets the requirements.
+var ErrNoMatchingProduct = errors.New("no product matches the requirements")
+
+// Requirements describes the minimum a product has to offer.
+// Zero fields are not checked.
+type Requirements struct {
+	MinCores int
+	// MinRAM is in MB.
+	MinRAM int
+	// MaxMonthlyPrice is in pence.
+	MaxMonthlyPrice int64
+	Family          string
+}
+
+// Matches reports whether the product meets the requirements
+// at the given monthly price.
+func (r Requirements) Matches(product Product, price int64) bool {
+	if r.Family != "" && product.Family != r.Family {
+		return false
+	}
+	if product.Specs.Cores < r.MinCores || product.Specs.RAM < r.MinRAM {
+		return false
+	}
+	if r.MaxMonthlyPrice > 0 && price > r.MaxMonthlyPrice {
+		return false
+	}
+	return true
+}
+
+// SelectProduct returns the cheapest product that meets the requirements,
+// using the monthly product prices in pricing.
+// Products without a price are skipped. Ties are broken by the
+// fewest cores, then the least RAM, then the product code.
+// Returns ErrNoMatchingProduct if no product matches.
+func SelectProduct(products Products, pricing Pricing, req Requirements) (Product, error) {
+	var (
+		best      Product
+		bestPrice int64
+		found     bool
+	)
+
+	for code, product := range products {
+		if product.Code == "" {
+			product.Code = code
+		}
+		price, ok := pricing.Products[product.Code]
+		if !ok || !req.Matches(product, price) {
+			continue
+		}
+		if found && !cheaper(product, price, best, bestPrice) {
+			continue
+		}
+		best, bestPrice, found = product, price, true
+	}
+
+	if !found {
+		return Product{}, ErrNoMatchingProduct
+	}
+	return best, nil
+}
+
+// cheaper reports whether a should be preferred over b.
+func cheaper(a Product, aPrice int64, b Product, bPrice int64) bool {
+	if aPrice != bPrice {
+		return aPrice < bPrice
+	}
+	if a.Specs.Cores != b.Specs.Cores {
+		return a.Specs.Cores < b.Specs.Cores
+	}
+	if a.Specs.RAM != b.Specs.RAM {
+		return a.Specs.RAM < b.Specs.RAM
+	}
+	return a.Code < b.Code
+}
@@ -0,0 +1,46 @@
+package vps_test
+
+import (
+	"errors"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestSelectProduct(t *testing.T) {
+	t.Parallel()
+
+	products := vpsapi.Products{
+		"VPSX4":  {Code: "VPSX4", Family: "vps", Specs: vpsapi.ProductSpecs{Cores: 1, RAM: 4096}},
+		"VPSX8":  {Code: "VPSX8", Family: "vps", Specs: vpsapi.ProductSpecs{Cores: 2, RAM: 8192}},
+		"VPSX16": {Code: "VPSX16", Family: "vps", Specs: vpsapi.ProductSpecs{Cores: 4, RAM: 16384}},
+		"VPSD8":  {Code: "VPSD8", Family: "dedicated", Specs: vpsapi.ProductSpecs{Cores: 2, RAM: 8192}},
+	}
+	pricing := vpsapi.Pricing{Products: map[string]int64{
+		"VPSX4":  1000,
+		"VPSX8":  2000,
+		"VPSX16": 4000,
+		"VPSD8":  1500,
+	}}
+
+	product, err := vpsapi.SelectProduct(products, pricing, vpsapi.Requirements{MinCores: 2})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if product.Code != "VPSD8" {
+		t.Fatalf("code=%q, want VPSD8", product.Code)
+	}
+
+	product, err = vpsapi.SelectProduct(products, pricing, vpsapi.Requirements{MinRAM: 8192, Family: "vps"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if product.Code != "VPSX8" {
+		t.Fatalf("code=%q, want VPSX8", product.Code)
+	}
+
+	_, err = vpsapi.SelectProduct(products, pricing, vpsapi.Requirements{MinCores: 4, MaxMonthlyPrice: 3000})
+	if !errors.Is(err, vpsapi.ErrNoMatchingProduct) {
+		t.Fatalf("err=%v, want ErrNoMatchingProduct", err)
+	}
+}
@@ -0,0 +1,140 @@
+package vps
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Servers maps server identifiers to Server details.
+type Servers map[string]Server
+
+// GetServers retrieves all provisioned VPSes.
+func (s *Service) GetServers(ctx context.Context) (Servers, error) {
+	var result Servers
+	if _, _, err := s.GetJSON(ctx, "/vps/servers", &result, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	for identifier, server := range result {
+		if server.Identifier == "" {
+			server.Identifier = identifier
+			result[identifier] = server
+		}
+	}
+
+	return result, nil
+}
+
+// ServerFilter selects servers returned by ListServers.
+// Empty fields match every server.
+type ServerFilter struct {
+	// Zone matches the zone code, e.g. "lon1".
+	Zone string
+	// Family matches the product family.
+	Family string
+	// Dormant matches the dormant state when set.
+	Dormant *bool
+	// Status matches the server status, e.g. StatusRunning.
+	Status Status
+	// HostServer matches the private cloud host the server runs on.
+	HostServer string
+	// NameContains matches names containing the substring, ignoring case.
+	NameContains string
+}
+
+// Matches reports whether the server is selected by the filter.
+func (f ServerFilter) Matches(server Server) bool {
+	if f.Zone != "" && server.Zone.Code != f.Zone {
+		return false
+	}
+	if f.Family != "" && server.Family != f.Family {
+		return false
+	}
+	if f.Dormant != nil && server.Dormant != *f.Dormant {
+		return false
+	}
+	if f.Status != "" && server.Status != f.Status {
+		return false
+	}
+	if f.HostServer != "" && server.HostServer != f.HostServer {
+		return false
+	}
+	if f.NameContains != "" && !strings.Contains(strings.ToLower(server.Name), strings.ToLower(f.NameContains)) {
+		return false
+	}
+	return true
+}
+
+// ListServers lists the VPSes matching the filter, sorted by identifier.
+// The API has no filtering parameters, so filtering is applied client-side.
+func (s *Service) ListServers(ctx context.Context, filter ServerFilter) ([]Server, error) {
+	all, err := s.GetServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]Server, 0, len(all))
+	for _, server := range all {
+		if filter.Matches(server) {
+			servers = append(servers, server)
+		}
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Identifier < servers[j].Identifier
+	})
+
+	return servers, nil
+}
+
+// ServersByHost lists the VPSes on each private cloud host,
+// keyed by host name and sorted by identifier.
+// Servers without a host server are not included.
+func (s *Service) ServersByHost(ctx context.Context) (map[string][]Server, error) {
+	servers, err := s.ListServers(ctx, ServerFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	byHost := make(map[string][]Server)
+	for _, server := range servers {
+		if server.HostServer == "" {
+			continue
+		}
+		byHost[server.HostServer] = append(byHost[server.HostServer], server)
+	}
+
+	return byHost, nil
+}
+
+// FindByName returns the VPS with the given name.
+// Returns ErrServerNotFound if no VPS has the name and
+// ErrAmbiguousName if more than one does.
+func (s *Service) FindByName(ctx context.Context, name string) (Server, error) {
+	servers, err := s.ListServers(ctx, ServerFilter{})
+	if err != nil {
+		return Server{}, err
+	}
+
+	var matches []Server
+	for _, server := range servers {
+		if server.Name == name {
+			matches = append(matches, server)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Server{}, &ErrServerNotFound{Name: name}
+	case 1:
+		return matches[0], nil
+	default:
+		identifiers := make([]string, len(matches))
+		for i, server := range matches {
+			identifiers[i] = server.Identifier
+		}
+		return Server{}, &ErrAmbiguousName{Name: name, Identifiers: identifiers}
+	}
+}
@@ -0,0 +1,141 @@
+package vps_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func serversHandler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("want GET")
+		}
+		_, _ = w.Write([]byte(`{
+			"web1": {"name":"Web One","host_server":"host1","status":"running","family":"vps","zone":{"code":"lon1","name":"London"},"dormant":false},
+			"web2": {"identifier":"web2","name":"Web Two","host_server":"host1","status":"powered off","family":"vps","zone":{"code":"lon1","name":"London"},"dormant":true},
+			"db1": {"identifier":"db1","name":"Database","host_server":"host2","status":"running","family":"vpsx","zone":{"code":"cam","name":"Cambridge"},"dormant":true}
+		}`))
+	}
+}
+
+func TestGetServers_FillsIdentifier(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers", serversHandler(t))
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	servers, err := c.VPS().GetServers(testContext())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(servers) != 3 {
+		t.Fatalf("len(servers)=%d, want 3", len(servers))
+	}
+	if got := servers["web1"].Identifier; got != "web1" {
+		t.Fatalf("identifier=%q, want web1", got)
+	}
+}
+
+func TestListServers_Filter(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers", serversHandler(t))
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	all, err := c.VPS().ListServers(testContext(), vpsapi.ServerFilter{})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(all) != 3 || all[0].Identifier != "db1" || all[2].Identifier != "web2" {
+		t.Fatalf("all=%+v", all)
+	}
+
+	dormant, err := c.VPS().ListServers(testContext(), vpsapi.ServerFilter{Zone: "lon1", Dormant: vpsapi.Bool(true)})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(dormant) != 1 || dormant[0].Identifier != "web2" {
+		t.Fatalf("dormant=%+v", dormant)
+	}
+
+	named, err := c.VPS().ListServers(testContext(), vpsapi.ServerFilter{NameContains: "web", Status: "running"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(named) != 1 || named[0].Identifier != "web1" {
+		t.Fatalf("named=%+v", named)
+	}
+}
+
+func TestServersByHost(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers", serversHandler(t))
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	byHost, err := c.VPS().ServersByHost(testContext())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(byHost) != 2 {
+		t.Fatalf("len(byHost)=%d, want 2", len(byHost))
+	}
+
+	var ids []string
+	for _, server := range byHost["host1"] {
+		ids = append(ids, server.Identifier)
+	}
+	if fmt.Sprint(ids) != "[web1 web2]" {
+		t.Fatalf("host1=%v, want [web1 web2]", ids)
+	}
+
+	servers, err := c.VPS().ListServers(testContext(), vpsapi.ServerFilter{HostServer: "host2"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(servers) != 1 || servers[0].Identifier != "db1" {
+		t.Fatalf("servers=%+v, want db1", servers)
+	}
+}
+
+func TestFindByName(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"web1": {"name":"web","status":"running"},
+			"web2": {"name":"web","status":"running"},
+			"db1": {"name":"db","status":"running"}
+		}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	server, err := c.VPS().FindByName(testContext(), "db")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Identifier != "db1" {
+		t.Fatalf("identifier=%q, want db1", server.Identifier)
+	}
+
+	_, err = c.VPS().FindByName(testContext(), "web")
+	var ambiguous *vpsapi.ErrAmbiguousName
+	if !errors.As(err, &ambiguous) || fmt.Sprint(ambiguous.Identifiers) != "[web1 web2]" {
+		t.Fatalf("err=%v, want ErrAmbiguousName", err)
+	}
+
+	_, err = c.VPS().FindByName(testContext(), "mail")
+	var notFound *vpsapi.ErrServerNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("err=%v, want ErrServerNotFound", err)
+	}
+}
@@ -5,9 +5,22 @@ import "github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 // BaseURL is the default base URL for VPS API requests.
 const BaseURL string = "https://api.mythic-beasts.com/beta"
 
+// RetryPolicy controls retries of provisioning requests.
+type RetryPolicy = transport.RetryPolicy
+
 // Service provides access to the VPS API.
 type Service struct {
 	transport.BaseService
+
+	// CreateRetry controls retries of the provisioning request made by Create.
+	// Before each retry the identifier is checked so a VPS is never
+	// provisioned twice. By default a single attempt is made.
+	CreateRetry RetryPolicy
+
+	// Protected lists identifiers, or path.Match patterns such as
+	// "prod-*", of VPSes that Delete refuses to remove unless
+	// DeleteOptions.Force is set.
+	Protected []string
 }
 
 // NewService constructs a VPS API service client.
@@ -0,0 +1,22 @@
+package vps
+
+import "github.com/paultibbetts/mythicbeasts-client-go/internal/sshkeys"
+
+// SSHKeys is a list of OpenSSH public keys.
+type SSHKeys = sshkeys.Keys
+
+// ParseSSHKeys splits a newline-separated list of public keys,
+// skipping blank lines and comments, and validates every key.
+func ParseSSHKeys(s string) (SSHKeys, error) {
+	return sshkeys.Parse(s)
+}
+
+// SetSSHKeys validates the keys and sets them on the request
+// with duplicates removed.
+func (r *CreateRequest) SetSSHKeys(keys SSHKeys) error {
+	if err := keys.Validate(); err != nil {
+		return err
+	}
+	r.SSHKeys = keys.Dedupe().String()
+	return nil
+}
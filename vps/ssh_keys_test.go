@@ -0,0 +1,65 @@
+package vps_test
+
+import (
+	"strings"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+const (
+	testKeyA = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC5cSqQNVmTIWz9901r8HB+DiwmnFYRWYXChyqigkzAA"
+	testKeyB = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f"
+)
+
+func TestParseSSHKeys(t *testing.T) {
+	t.Parallel()
+
+	keys, err := vpsapi.ParseSSHKeys("# deploy keys\n" + testKeyA + " alice\n\n" + testKeyB + "\n" + testKeyA + " alice@laptop\n")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("len(keys)=%d, want 3", len(keys))
+	}
+
+	deduped := keys.Dedupe()
+	if len(deduped) != 2 || deduped[0] != testKeyA+" alice" {
+		t.Fatalf("deduped=%v", deduped)
+	}
+	if deduped.String() != testKeyA+" alice\n"+testKeyB {
+		t.Fatalf("string=%q", deduped.String())
+	}
+}
+
+func TestParseSSHKeys_Malformed(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{
+		"ssh-ed25519",
+		"ssh-foo AAAAC3NzaC1lZDI1NTE5AAAAIC5cSqQNVmTIWz9901r8HB+DiwmnFYRWYXChyqigkzAA",
+		"ssh-ed25519 not-base64!",
+		"ssh-rsa AAAAC3NzaC1lZDI1NTE5AAAAIC5cSqQNVmTIWz9901r8HB+DiwmnFYRWYXChyqigkzAA",
+	} {
+		if _, err := vpsapi.ParseSSHKeys(key); err == nil {
+			t.Fatalf("ParseSSHKeys(%q): expected error", key)
+		}
+	}
+}
+
+func TestCreateRequest_SetSSHKeys(t *testing.T) {
+	t.Parallel()
+
+	var req vpsapi.CreateRequest
+	if err := req.SetSSHKeys(vpsapi.SSHKeys{testKeyA, testKeyB, testKeyA}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req.SSHKeys != testKeyA+"\n"+testKeyB {
+		t.Fatalf("ssh_keys=%q", req.SSHKeys)
+	}
+
+	err := req.SetSSHKeys(vpsapi.SSHKeys{"ssh-ed25519 AAAA"})
+	if err == nil || !strings.Contains(err.Error(), "ssh key 1") {
+		t.Fatalf("err=%v, want error for key 1", err)
+	}
+}
@@ -0,0 +1,41 @@
+package vps
+
+// Status represents the status of a VPS as reported by the API.
+// Values without a constant are kept as reported.
+type Status string
+
+const (
+	StatusRunning      Status = "running"
+	StatusPoweredOff   Status = "powered off"
+	StatusStopped      Status = "stopped"
+	StatusShutoff      Status = "shutoff"
+	StatusDormant      Status = "dormant"
+	StatusProvisioning Status = "provisioning"
+	StatusInstalling   Status = "installing"
+	StatusBooting      Status = "booting"
+	StatusShuttingDown Status = "shutting down"
+	StatusDeleting     Status = "deleting"
+)
+
+// IsTerminal reports whether the status is a settled state
+// that will not change without an action.
+func (s Status) IsTerminal() bool {
+	switch s {
+	case StatusRunning, StatusPoweredOff, StatusStopped, StatusShutoff, StatusDormant:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTransitional reports whether the status is a state the VPS
+// is expected to move on from by itself.
+// Unknown statuses are neither terminal nor transitional.
+func (s Status) IsTransitional() bool {
+	switch s {
+	case StatusProvisioning, StatusInstalling, StatusBooting, StatusShuttingDown, StatusDeleting:
+		return true
+	default:
+		return false
+	}
+}
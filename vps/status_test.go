@@ -0,0 +1,46 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	if !vpsapi.StatusRunning.IsTerminal() || vpsapi.StatusRunning.IsTransitional() {
+		t.Fatalf("running should be terminal")
+	}
+	if vpsapi.StatusProvisioning.IsTerminal() || !vpsapi.StatusProvisioning.IsTransitional() {
+		t.Fatalf("provisioning should be transitional")
+	}
+
+	unknown := vpsapi.Status("rescue")
+	if unknown.IsTerminal() || unknown.IsTransitional() {
+		t.Fatalf("unknown status should be neither terminal nor transitional")
+	}
+}
+
+func TestStatus_RoundTripsUnknownValues(t *testing.T) {
+	t.Parallel()
+
+	var server vpsapi.Server
+	if err := json.Unmarshal([]byte(`{"status":"rescue"}`), &server); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Status != "rescue" {
+		t.Fatalf("status=%q, want rescue", server.Status)
+	}
+
+	body, err := json.Marshal(server)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	var raw map[string]any
+	_ = json.Unmarshal(body, &raw)
+	if raw["status"] != "rescue" {
+		t.Fatalf("status=%v, want rescue", raw["status"])
+	}
+}
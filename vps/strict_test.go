@@ -0,0 +1,55 @@
+package vps_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/paultibbetts/mythicbeasts-client-go"
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/ok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"ok","specs":{"disk_size":10240}}`))
+	})
+	mux.HandleFunc("/vps/servers/renamed", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"renamed","specs":{"disk_size_mb":10240}}`))
+	})
+	mux.HandleFunc("/vps/servers/retyped", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"retyped","specs":{"disk_size":"10G"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, _ := mythicbeasts.NewClient("", "", mythicbeasts.WithStrictDecoding())
+	c.VPS().BaseURL = srv.URL
+
+	if _, err := c.VPS().Get(testContext(), "ok"); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	for id, field := range map[string]string{
+		"renamed": "specs.disk_size_mb",
+		"retyped": "specs.disk_size",
+	} {
+		_, err := c.VPS().Get(testContext(), id)
+		var malformed *mythicbeasts.ErrMalformedResponse
+		if !errors.As(err, &malformed) || malformed.Field != field {
+			t.Fatalf("%s: err=%v, want ErrMalformedResponse for %s", id, err, field)
+		}
+		var vpsMalformed *vpsapi.ErrMalformedResponse
+		if !errors.As(err, &vpsMalformed) {
+			t.Fatalf("%s: err=%v, want vps.ErrMalformedResponse", id, err)
+		}
+	}
+
+	lenient, srv2 := newTestClient(t, mux)
+	defer srv2.Close()
+	if _, err := lenient.VPS().Get(testContext(), "renamed"); err != nil {
+		t.Fatalf("lenient err: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package vps
+
+import "context"
+
+// UpdateAndGetOptions controls UpdateAndGet.
+type UpdateAndGetOptions struct {
+	// WaitForApplied polls until the server reports the updated values.
+	WaitForApplied bool
+	// Wait controls polling when WaitForApplied is set.
+	Wait WaitOptions
+}
+
+// UpdateAndGet applies the update and returns the refreshed server.
+// Only the first UpdateAndGetOptions is used. With WaitForApplied set
+// it polls until the server reports the requested values, see
+// UpdateRequest.AppliedTo, and returns ErrWaitTimeout if it never does.
+func (s *Service) UpdateAndGet(ctx context.Context, identifier string, req UpdateRequest, opts ...UpdateAndGetOptions) (Server, error) {
+	var o UpdateAndGetOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	if _, err := s.Update(ctx, identifier, req); err != nil {
+		return Server{}, err
+	}
+
+	if !o.WaitForApplied {
+		return s.Get(ctx, identifier)
+	}
+
+	return s.waitFor(ctx, identifier, "updated", o.Wait, req.AppliedTo)
+}
+
+// AppliedTo reports whether the server shows every value set in the
+// request. Fields the server does not report, such as VNC settings
+// and the billing period, are not compared.
+func (r UpdateRequest) AppliedTo(server Server) bool {
+	if r.Product != nil && *r.Product != server.Product {
+		return false
+	}
+	if r.Specs != nil {
+		if r.Specs.DiskSize != nil && *r.Specs.DiskSize != server.Specs.DiskSize {
+			return false
+		}
+		if r.Specs.ExtraCores != nil && *r.Specs.ExtraCores != server.Specs.ExtraCores {
+			return false
+		}
+		if r.Specs.ExtraRAM != nil && *r.Specs.ExtraRAM != server.Specs.ExtraRAM {
+			return false
+		}
+	}
+	if r.Name != nil && *r.Name != server.Name {
+		return false
+	}
+	if r.clearName && server.Name != "" {
+		return false
+	}
+	if r.BootDevice != nil && *r.BootDevice != server.BootDevice {
+		return false
+	}
+	if r.ISOImage != nil && *r.ISOImage != server.ISOImage {
+		return false
+	}
+	if r.clearISOImage && server.ISOImage != "" {
+		return false
+	}
+	if r.CPUMode != nil && *r.CPUMode != server.CPUMode {
+		return false
+	}
+	if r.NetDevice != nil && *r.NetDevice != server.NetDevice {
+		return false
+	}
+	if r.DiskBus != nil && *r.DiskBus != server.DiskBus {
+		return false
+	}
+	if r.Tablet != nil && *r.Tablet != server.Tablet {
+		return false
+	}
+	if r.Dormant != nil && *r.Dormant != server.Dormant {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package vps_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestUpdateAndGet(t *testing.T) {
+	t.Parallel()
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/web", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			_, _ = w.Write([]byte(`{"message":"ok"}`))
+		case http.MethodGet:
+			gets++
+			if gets < 3 {
+				_, _ = w.Write([]byte(`{"identifier":"web","specs":{"disk_size":10240}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"identifier":"web","specs":{"disk_size":20480}}`))
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	req := vpsapi.NewUpdateRequest()
+	specs := vpsapi.NewUpdateSpecs()
+	specs.SetDiskSize(20480)
+	req.SetSpecs(specs)
+
+	server, err := c.VPS().UpdateAndGet(testContext(), "web", req)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Specs.DiskSize != 10240 || gets != 1 {
+		t.Fatalf("server=%+v gets=%d, want single refresh", server, gets)
+	}
+
+	server, err = c.VPS().UpdateAndGet(testContext(), "web", req, vpsapi.UpdateAndGetOptions{
+		WaitForApplied: true,
+		Wait:           vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Specs.DiskSize != 20480 || gets != 3 {
+		t.Fatalf("server=%+v gets=%d, want applied disk size", server, gets)
+	}
+}
+
+func TestUpdateAndGet_Timeout(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/web", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			_, _ = w.Write([]byte(`{"message":"ok"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"identifier":"web","product":"VPSX4","status":"running"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	req := vpsapi.NewUpdateRequest()
+	req.SetProduct("VPSX8")
+
+	_, err := c.VPS().UpdateAndGet(testContext(), "web", req, vpsapi.UpdateAndGetOptions{
+		WaitForApplied: true,
+		Wait:           vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: 10 * time.Millisecond},
+	})
+	var timeout *vpsapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Want != "updated" {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+}
@@ -2,11 +2,15 @@ package vps
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
 )
 
 // NewUserData represents the data required to create
@@ -78,24 +82,134 @@ func (s *Service) GetUserDataSnippets(ctx context.Context) (UserDataSnippets, er
 	return snippets, nil
 }
 
+// GetUserDataByName retrieves the user data snippet with the given name.
+// Returns ErrUserDataNotFound if no snippet has the name, and
+// ErrAmbiguousUserData if more than one does.
 func (s *Service) GetUserDataByName(ctx context.Context, name string) (UserData, error) {
-	snippets, err := s.GetUserDataSnippets(ctx)
+	id, err := s.userDataIDByName(ctx, name)
 	if err != nil {
 		return UserData{}, err
 	}
 
-	var id int64
+	return s.GetUserData(ctx, id)
+}
+
+// ResolveUserDataID returns the ID of the user data snippet referenced
+// by ref. A ref is looked up as a name first, so a snippet named "42"
+// wins over the snippet with ID 42, and is only used as an ID when no
+// snippet has it as its name.
+// Returns ErrUserDataNotFound if ref is neither a name nor an ID, and
+// ErrAmbiguousUserData if more than one snippet has the name.
+func (s *Service) ResolveUserDataID(ctx context.Context, ref string) (int64, error) {
+	id, err := s.userDataIDByName(ctx, ref)
+	var notFound *ErrUserDataNotFound
+	if errors.As(err, &notFound) {
+		if id, parseErr := strconv.ParseInt(ref, 10, 64); parseErr == nil {
+			return id, nil
+		}
+	}
+
+	return id, err
+}
+
+// userDataIDByName returns the ID of the only snippet named name.
+func (s *Service) userDataIDByName(ctx context.Context, name string) (int64, error) {
+	snippets, err := s.GetUserDataSnippets(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var ids []int64
 	for _, data := range snippets {
 		if data.Name == name {
-			id = data.ID
+			ids = append(ids, data.ID)
 		}
 	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
 
-	if id == 0 {
-		return UserData{}, &ErrUserDataNotFound{Name: name}
+	switch len(ids) {
+	case 0:
+		return 0, &ErrUserDataNotFound{Name: name}
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, &ErrAmbiguousUserData{Name: name, IDs: ids}
 	}
+}
 
-	return s.GetUserData(ctx, id)
+// ListUserData lists the User Data snippets sorted by name, then ID.
+// Snippets in the list do not include their data.
+func (s *Service) ListUserData(ctx context.Context) ([]UserData, error) {
+	snippets, err := s.GetUserDataSnippets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]UserData, 0, len(snippets))
+	for _, data := range snippets {
+		list = append(list, data)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].ID < list[j].ID
+	})
+
+	return list, nil
+}
+
+// FindUserData returns the first snippet, in ListUserData order,
+// for which match reports true. It reports false if none match.
+func (s *Service) FindUserData(ctx context.Context, match func(UserData) bool) (UserData, bool, error) {
+	list, err := s.ListUserData(ctx)
+	if err != nil {
+		return UserData{}, false, err
+	}
+
+	for _, data := range list {
+		if match(data) {
+			return data, true, nil
+		}
+	}
+
+	return UserData{}, false, nil
+}
+
+// UpsertUserData updates the data of the User Data snippet with the
+// given name, or creates the snippet if there is none, and returns it.
+// If the snippet is created concurrently and the create is rejected
+// with a conflict, the new snippet is updated instead.
+func (s *Service) UpsertUserData(ctx context.Context, name string, data string) (UserData, error) {
+	byName := func(d UserData) bool { return d.Name == name }
+
+	existing, ok, err := s.FindUserData(ctx, byName)
+	if err != nil {
+		return UserData{}, err
+	}
+
+	if !ok {
+		created, err := s.CreateUserData(ctx, NewUserData{Name: name, Data: data})
+		var apiErr *transport.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+			return created, err
+		}
+
+		existing, ok, err = s.FindUserData(ctx, byName)
+		if err != nil {
+			return UserData{}, err
+		}
+		if !ok {
+			return UserData{}, &ErrUserDataNotFound{Name: name}
+		}
+	}
+
+	if err := s.UpdateUserData(ctx, existing.ID, UpdateUserData{Data: data}); err != nil {
+		return UserData{}, err
+	}
+
+	return s.GetUserData(ctx, existing.ID)
 }
 
 // UpdateUserData updates the User Data snippet with the given ID.
@@ -0,0 +1,91 @@
+package vps
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// MaxUserDataSize is the largest user data payload, in bytes,
+// accepted by ValidateUserData.
+const MaxUserDataSize = 64 * 1024
+
+// userDataHeaders are the first-line markers cloud-init recognises.
+var userDataHeaders = []string{
+	"#cloud-config",
+	"#!",
+	"#include",
+	"#cloud-boothook",
+	"#part-handler",
+	"#upstart-job",
+	"## template: jinja",
+	"Content-Type: multipart/",
+}
+
+// ErrInvalidUserData indicates a user data payload would
+// not be accepted or not be understood by cloud-init.
+type ErrInvalidUserData struct {
+	Reason string
+}
+
+func (e *ErrInvalidUserData) Error() string {
+	return fmt.Sprintf("invalid user data: %s", e.Reason)
+}
+
+// ValidateUserData checks a user data payload before it is uploaded.
+// The payload must be no larger than MaxUserDataSize and start with a
+// header cloud-init recognises, such as "#cloud-config" or "#!".
+func ValidateUserData(data string) error {
+	if data == "" {
+		return &ErrInvalidUserData{Reason: "empty payload"}
+	}
+	if len(data) > MaxUserDataSize {
+		return &ErrInvalidUserData{Reason: fmt.Sprintf("%d bytes exceeds the %d byte limit", len(data), MaxUserDataSize)}
+	}
+
+	for _, header := range userDataHeaders {
+		if strings.HasPrefix(data, header) {
+			return nil
+		}
+	}
+
+	firstLine, _, _ := strings.Cut(data, "\n")
+	return &ErrInvalidUserData{Reason: fmt.Sprintf("unrecognised header %q", firstLine)}
+}
+
+// Validate checks the snippet has a name and valid data.
+// See ValidateUserData.
+func (d NewUserData) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return &ErrInvalidUserData{Reason: "name is required"}
+	}
+	return ValidateUserData(d.Data)
+}
+
+// RenderUserData executes tmpl as a text/template with vars,
+// e.g. to inject a hostname or SSH keys into a cloud-config.
+// Referencing a variable missing from vars is an error.
+func RenderUserData(tmpl string, vars map[string]any) (string, error) {
+	t, err := template.New("user_data").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse user data template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := t.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("render user data template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// SetUserDataTemplate renders tmpl with vars and attaches the result
+// inline as UserDataString, replacing any UserData snippet reference.
+func (r *CreateRequest) SetUserDataTemplate(tmpl string, vars map[string]any) error {
+	data, err := RenderUserData(tmpl, vars)
+	if err != nil {
+		return err
+	}
+	r.UserData = ""
+	r.UserDataString = data
+	return nil
+}
@@ -0,0 +1,74 @@
+package vps_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestValidateUserData(t *testing.T) {
+	t.Parallel()
+
+	for _, valid := range []string{
+		"#cloud-config\npackages: [nginx]\n",
+		"#!/bin/sh\necho hi\n",
+		"Content-Type: multipart/mixed; boundary=x\n",
+	} {
+		if err := vpsapi.ValidateUserData(valid); err != nil {
+			t.Fatalf("ValidateUserData(%q): %v", valid, err)
+		}
+	}
+
+	for _, invalid := range []string{
+		"",
+		"packages: [nginx]\n",
+		"#cloud-config\n" + strings.Repeat("a", vpsapi.MaxUserDataSize),
+	} {
+		var target *vpsapi.ErrInvalidUserData
+		if err := vpsapi.ValidateUserData(invalid); !errors.As(err, &target) {
+			t.Fatalf("err=%v, want ErrInvalidUserData", err)
+		}
+	}
+}
+
+func TestNewUserData_Validate(t *testing.T) {
+	t.Parallel()
+
+	if err := (vpsapi.NewUserData{Data: "#cloud-config\n"}).Validate(); err == nil {
+		t.Fatalf("expected error for missing name")
+	}
+	if err := (vpsapi.NewUserData{Name: "web", Data: "#cloud-config\n"}).Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestRenderUserData(t *testing.T) {
+	t.Parallel()
+
+	tmpl := "#cloud-config\nhostname: {{ .hostname }}\n"
+	got, err := vpsapi.RenderUserData(tmpl, map[string]any{"hostname": "web1"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if got != "#cloud-config\nhostname: web1\n" {
+		t.Fatalf("got=%q", got)
+	}
+
+	if _, err := vpsapi.RenderUserData(tmpl, map[string]any{}); err == nil {
+		t.Fatalf("expected error for missing variable")
+	}
+}
+
+func TestCreateRequest_SetUserDataTemplate(t *testing.T) {
+	t.Parallel()
+
+	req := vpsapi.CreateRequest{UserData: "base"}
+	if err := req.SetUserDataTemplate("#cloud-config\nhostname: {{ .hostname }}\n", map[string]any{"hostname": "web1"}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if req.UserData != "" || !strings.Contains(req.UserDataString, "hostname: web1") {
+		t.Fatalf("req=%+v", req)
+	}
+}
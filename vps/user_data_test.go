@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
 )
@@ -426,3 +427,167 @@ func TestUserData_Update_UnexpectedStatus(t *testing.T) {
 		t.Fatalf("err=%q want %q", err.Error(), want)
 	}
 }
+
+func TestUserData_ListAndFind(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/user-data", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user_data":{
+			"3":{"id":3,"name":"web","size":10},
+			"1":{"id":1,"name":"db","size":20},
+			"2":{"id":"2","name":"base","size":"30"}
+		}}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	list, err := c.VPS().ListUserData(testContext())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	var names []string
+	for _, data := range list {
+		names = append(names, data.Name)
+	}
+	if strings.Join(names, ",") != "base,db,web" {
+		t.Fatalf("names=%v, want base,db,web", names)
+	}
+
+	found, ok, err := c.VPS().FindUserData(testContext(), func(data vpsapi.UserData) bool {
+		return data.Size >= 20
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !ok || found.Name != "base" {
+		t.Fatalf("found=%+v ok=%v, want base", found, ok)
+	}
+
+	_, ok, err = c.VPS().FindUserData(testContext(), func(data vpsapi.UserData) bool { return false })
+	if err != nil || ok {
+		t.Fatalf("ok=%v err=%v, want no match", ok, err)
+	}
+}
+
+func TestUserData_Upsert(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		existing string
+		conflict bool
+		want     []string
+	}{
+		{name: "updates existing", existing: `{"7":{"id":7,"name":"web","size":3}}`, want: []string{"PUT"}},
+		{name: "creates missing", existing: `{}`, want: []string{"POST"}},
+		{name: "updates after conflict", existing: `{}`, conflict: true, want: []string{"POST", "PUT"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var calls []string
+			listed := 0
+			mux := http.NewServeMux()
+			mux.HandleFunc("/vps/user-data", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					listed++
+					if tc.conflict && listed > 1 {
+						_, _ = w.Write([]byte(`{"user_data":{"7":{"id":7,"name":"web","size":3}}}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"user_data":` + tc.existing + `}`))
+				case http.MethodPost:
+					calls = append(calls, r.Method)
+					if tc.conflict {
+						w.WriteHeader(http.StatusConflict)
+						return
+					}
+					_, _ = w.Write([]byte(`{"id":7,"name":"web","data":"new","size":3}`))
+				}
+			})
+			mux.HandleFunc("/vps/user-data/7", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					calls = append(calls, r.Method)
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":7,"name":"web","data":"new","size":3}`))
+			})
+			c, srv := newTestClient(t, mux)
+			defer srv.Close()
+
+			got, err := c.VPS().UpsertUserData(testContext(), "web", "new")
+			if err != nil {
+				t.Fatalf("err: %v", err)
+			}
+			if got.ID != 7 || got.Data != "new" {
+				t.Fatalf("got=%+v", got)
+			}
+			if strings.Join(calls, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("calls=%v, want %v", calls, tc.want)
+			}
+		})
+	}
+}
+
+func TestCreate_ResolveUserData(t *testing.T) {
+	t.Parallel()
+	var sent string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/user-data", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user_data":{
+			"12":{"id":12,"name":"web","size":10},
+			"13":{"id":13,"name":"dup","size":10},
+			"14":{"id":14,"name":"dup","size":10},
+			"15":{"id":15,"name":"7","size":10}}}`))
+	})
+	mux.HandleFunc("/vps/servers/new", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			var req vpsapi.CreateRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			sent = req.UserData
+			w.Header().Set("Location", "/queue/vps/new")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			_, _ = w.Write([]byte(`{"identifier":"new","status":"running"}`))
+		}
+	})
+	mux.HandleFunc("/queue/vps/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/vps/servers/new")
+		w.WriteHeader(http.StatusSeeOther)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	opts := vpsapi.CreateOptions{ResolveUserData: true, PollInterval: time.Millisecond}
+
+	if _, err := c.VPS().Create(testContext(), "new", vpsapi.CreateRequest{Product: "VPSX4", UserData: "web"}, opts); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if sent != "12" {
+		t.Fatalf("user_data=%q, want 12", sent)
+	}
+
+	sent = ""
+	_, err := c.VPS().Create(testContext(), "new", vpsapi.CreateRequest{Product: "VPSX4", UserData: "missing"}, opts)
+	var notFound *vpsapi.ErrUserDataNotFound
+	if !errors.As(err, &notFound) || sent != "" {
+		t.Fatalf("err=%v sent=%q, want ErrUserDataNotFound before submission", err, sent)
+	}
+
+	_, err = c.VPS().Create(testContext(), "new", vpsapi.CreateRequest{Product: "VPSX4", UserData: "dup"}, opts)
+	var ambiguous *vpsapi.ErrAmbiguousUserData
+	if !errors.As(err, &ambiguous) || len(ambiguous.IDs) != 2 || ambiguous.IDs[0] != 13 {
+		t.Fatalf("err=%v, want ErrAmbiguousUserData", err)
+	}
+
+	id, err := c.VPS().ResolveUserDataID(testContext(), "42")
+	if err != nil || id != 42 {
+		t.Fatalf("id=%d err=%v, want 42", id, err)
+	}
+
+	id, err = c.VPS().ResolveUserDataID(testContext(), "7")
+	if err != nil || id != 15 {
+		t.Fatalf("id=%d err=%v, want 15 for the snippet named 7", id, err)
+	}
+}
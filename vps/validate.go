@@ -0,0 +1,58 @@
+package vps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Validate checks the request for problems the API would reject,
+// returning an error for every problem found joined with errors.Join.
+// It does not make any API requests; use ValidateDiskSize to also
+// check the disk size against the available sizes.
+func (r CreateRequest) Validate() error {
+	var errs []error
+
+	if strings.TrimSpace(r.Product) == "" {
+		errs = append(errs, errors.New("product is required"))
+	}
+	if r.DiskSize <= 0 {
+		errs = append(errs, fmt.Errorf("disk size must be positive, got %d", r.DiskSize))
+	}
+	switch r.DiskType {
+	case "", "ssd", "hdd":
+	default:
+		errs = append(errs, &ErrInvalidValue{Field: "disk_type", Value: r.DiskType})
+	}
+	if r.UserData != "" && r.UserDataString != "" {
+		errs = append(errs, errors.New("user_data and user_data_string are mutually exclusive"))
+	}
+	if r.Zone != "" && r.HostServer != "" {
+		errs = append(errs, errors.New("zone and host_server are mutually exclusive"))
+	}
+	if r.SSHKeys != "" {
+		if _, err := ParseSSHKeys(r.SSHKeys); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if strings.TrimSpace(r.SSHKeys) == "" && (r.VNC == nil || r.VNC.Mode == VNCModeDisabled) {
+		errs = append(errs, errors.New("ssh_keys or vnc access is required"))
+	}
+	errs = append(errs, r.validateEnums())
+
+	return errors.Join(errs...)
+}
+
+// ValidateDiskSize checks that the disk size of the request is one of the
+// sizes available for its disk type. An empty disk type is treated as SSD.
+// The error names the nearest available size.
+func (r CreateRequest) ValidateDiskSize(sizes DiskSizes) error {
+	if sizes.Contains(r.DiskType, r.DiskSize) {
+		return nil
+	}
+	nearest, ok := sizes.Nearest(r.DiskType, r.DiskSize)
+	if !ok {
+		return fmt.Errorf("disk size %d is not available, no sizes for disk type %q", r.DiskSize, r.DiskType)
+	}
+	return fmt.Errorf("disk size %d is not available, nearest is %d", r.DiskSize, nearest)
+}
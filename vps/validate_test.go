@@ -0,0 +1,77 @@
+package vps_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestCreateRequest_Validate_OK(t *testing.T) {
+	t.Parallel()
+
+	req := vpsapi.CreateRequest{
+		Product:  "VPSX4",
+		DiskSize: 10240,
+		SSHKeys:  "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC5cSqQNVmTIWz9901r8HB+DiwmnFYRWYXChyqigkzAA",
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	req = vpsapi.CreateRequest{
+		Product:  "VPSX4",
+		DiskSize: 10240,
+		VNC:      &vpsapi.VNCSettings{Mode: vpsapi.VNCModePublic},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestCreateRequest_Validate_ReportsEveryProblem(t *testing.T) {
+	t.Parallel()
+
+	req := vpsapi.CreateRequest{
+		UserData:       "web",
+		UserDataString: "#cloud-config",
+		Zone:           "lon",
+		HostServer:     "host1",
+		CPUMode:        "turbo",
+	}
+	err := req.Validate()
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	for _, want := range []string{
+		"product is required",
+		"disk size must be positive",
+		"user_data and user_data_string are mutually exclusive",
+		"zone and host_server are mutually exclusive",
+		"ssh_keys or vnc access is required",
+		`invalid cpu_mode: "turbo"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("err=%q, missing %q", err, want)
+		}
+	}
+
+	var invalid *vpsapi.ErrInvalidValue
+	if !errors.As(err, &invalid) {
+		t.Fatalf("err=%v, want ErrInvalidValue", err)
+	}
+}
+
+func TestCreateRequest_ValidateDiskSize(t *testing.T) {
+	t.Parallel()
+	sizes := vpsapi.DiskSizes{SSD: []int64{5120, 10240}, HDD: []int64{102400}}
+
+	if err := (vpsapi.CreateRequest{DiskSize: 10240}).ValidateDiskSize(sizes); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if err := (vpsapi.CreateRequest{DiskSize: 10240, DiskType: "hdd"}).ValidateDiskSize(sizes); err == nil {
+		t.Fatalf("expected error for unavailable hdd size")
+	}
+}
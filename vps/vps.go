@@ -1,40 +1,41 @@
 package vps
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"strconv"
 	"strings"
-	"time"
 )
 
 // Server represents a provisioned VPS.
 type Server struct {
 	Identifier string      `json:"identifier"`
 	Name       string      `json:"name"`
-	Status     string      `json:"status"`
+	Status     Status      `json:"status"`
 	HostServer string      `json:"host_server"`
 	Zone       ServerZone  `json:"zone"`
 	Product    string      `json:"product"`
 	Family     string      `json:"family"`
-	CPUMode    string      `json:"cpu_mode"`
-	NetDevice  string      `json:"net_device"`
-	DiskBus    string      `json:"disk_bus"`
+	CPUMode    CPUMode     `json:"cpu_mode"`
+	NetDevice  NetDevice   `json:"net_device"`
+	DiskBus    DiskBus     `json:"disk_bus"`
 	Tablet     bool        `json:"tablet"`
 	Price      float64     `json:"price"`
 	Period     string      `json:"period"`
 	ISOImage   string      `json:"iso_image"`
 	Dormant    bool        `json:"dormant"`
-	BootDevice string      `json:"boot_device"`
+	BootDevice BootDevice  `json:"boot_device"`
 	IPv4       []string    `json:"ipv4"`
 	IPv6       []string    `json:"ipv6"`
 	Specs      ServerSpecs `json:"specs"`
 	Macs       []string    `json:"macs"`
 	SSHProxy   SSHProxy    `json:"ssh_proxy"`
 	VNC        VNC         `json:"vnc"`
+
+	// Raw is the JSON the server was decoded from. See Extra.
+	Raw json.RawMessage `json:"-"`
 }
 
 // ServerZone represents the Zone (datacentre) that a VPS
@@ -64,12 +65,18 @@ type SSHProxy struct {
 
 // VNC represents VNC connection details for a provisioned VPS.
 type VNC struct {
-	Mode     string `json:"mode"`
-	Password string `json:"password"`
-	IPv4     string `json:"ipv4"`
-	IPv6     string `json:"ipv6"`
-	Port     int64  `json:"port"`
-	Display  int64  `json:"display"`
+	Mode     VNCMode `json:"mode"`
+	Password string  `json:"password"`
+	IPv4     string  `json:"ipv4"`
+	IPv6     string  `json:"ipv6"`
+	Port     int64   `json:"port"`
+	Display  int64   `json:"display"`
+}
+
+// VNCSettings represents the configurable VNC settings of a VPS.
+type VNCSettings struct {
+	Mode     VNCMode `json:"mode,omitempty"`
+	Password string  `json:"password,omitempty"`
 }
 
 // Get retrieves the details for the VPS with the given identifier.
@@ -91,26 +98,31 @@ func (s *Service) Get(ctx context.Context, identifier string) (Server, error) {
 // CreateRequest represents the data required for provisioning a VPS.
 // Some fields are optional and some are only used on creation.
 type CreateRequest struct {
-	Product        string `json:"product"`
-	Name           string `json:"name,omitempty"`
-	HostServer     string `json:"host_server,omitempty"`
-	Hostname       string `json:"hostname,omitempty"`
-	SetForwardDNS  bool   `json:"set_forward_dns,omitempty"`
-	SetReverseDNS  bool   `json:"set_reverse_dns,omitempty"`
-	DiskType       string `json:"disk_type,omitempty"`
-	DiskSize       int64  `json:"disk_size"`
-	ExtraCores     int64  `json:"extra_cores,omitempty"`
-	ExtraRAM       int64  `json:"extra_ram,omitempty"`
-	IPv4           bool   `json:"ipv4,omitempty"`
-	Zone           string `json:"zone,omitempty"`
-	Image          string `json:"image,omitempty"`
-	UserData       string `json:"user_data,omitempty"` // id or name
-	UserDataString string `json:"user_data_string,omitempty"`
-	SSHKeys        string `json:"ssh_keys,omitempty"`
-	CPUMode        string `json:"cpu_mode,omitempty"`
-	NetDevice      string `json:"net_device,omitempty"`
-	DiskBus        string `json:"disk_bus,omitempty"`
-	Tablet         *bool  `json:"tablet,omitempty"`
+	Product        string    `json:"product"`
+	Name           string    `json:"name,omitempty"`
+	HostServer     string    `json:"host_server,omitempty"`
+	Hostname       string    `json:"hostname,omitempty"`
+	SetForwardDNS  bool      `json:"set_forward_dns,omitempty"`
+	SetReverseDNS  bool      `json:"set_reverse_dns,omitempty"`
+	DiskType       string    `json:"disk_type,omitempty"`
+	DiskSize       int64     `json:"disk_size"`
+	ExtraCores     int64     `json:"extra_cores,omitempty"`
+	ExtraRAM       int64     `json:"extra_ram,omitempty"`
+	IPv4           bool      `json:"ipv4,omitempty"`
+	Zone           string    `json:"zone,omitempty"`
+	Image          string    `json:"image,omitempty"`
+	UserData       string    `json:"user_data,omitempty"` // id or name
+	UserDataString string    `json:"user_data_string,omitempty"`
+	SSHKeys        string    `json:"ssh_keys,omitempty"`
+	CPUMode        CPUMode   `json:"cpu_mode,omitempty"`
+	NetDevice      NetDevice `json:"net_device,omitempty"`
+	DiskBus        DiskBus   `json:"disk_bus,omitempty"`
+	Tablet         *bool     `json:"tablet,omitempty"`
+
+	// Period is the billing period. If empty the API default is used.
+	Period ProductPeriod `json:"period,omitempty"`
+
+	VNC *VNCSettings `json:"vnc,omitempty"`
 }
 
 // SetTablet includes the tablet field in create requests.
@@ -126,80 +138,32 @@ func Bool(v bool) *bool { return &v }
 // request parameters.
 //
 // It blocks until the server becomes live or the timeout
-// is reached.
+// is reached. The wait can be tuned with CreateOptions;
+// only the first is used. Set CreateOptions.PickHost to
+// choose a private cloud host automatically, and
+// CreateOptions.ResolveUserData to send a user data name as its ID.
 // Returns ErrIdentifierConflict if the identifier is already in use.
-func (s *Service) Create(ctx context.Context, identifier string, server CreateRequest) (Server, error) {
-	requestURL := fmt.Sprintf("/vps/servers/%s", identifier)
-
-	requestJson, err := json.Marshal(server)
-	if err != nil {
-		return Server{}, err
-	}
-
-	req, err := s.NewRequest(ctx, http.MethodPost, requestURL, bytes.NewBuffer(requestJson))
-	if err != nil {
-		return Server{}, err
-	}
-	req.Header.Add("Content-Type", "application/json")
-
-	res, err := s.Do(req)
-	if err != nil {
-		return Server{}, err
-	}
-
-	body, err := s.Body(res)
-	if err != nil {
-		return Server{}, fmt.Errorf("unexpected status %d", res.StatusCode)
-	}
-
-	if res.StatusCode == http.StatusConflict {
-		return Server{}, &ErrIdentifierConflict{Identifier: identifier}
-	}
-
-	if res.StatusCode != http.StatusAccepted {
-		return Server{}, fmt.Errorf("unexpected status %d: %s", res.StatusCode, string(body))
-	}
-
-	pollURL := res.Header.Get("Location")
-	if pollURL == "" {
-		return Server{}, fmt.Errorf("missing header location for polling")
-	}
-
-	isVPSReady := func(data map[string]any, identifier string) (string, bool) {
-		status, _ := data["status"].(string)
-		log.Printf("vps[%s] provisioning status=%q", identifier, status)
-		if status == "running" {
-			return fmt.Sprintf("/vps/servers/%s", identifier), true
+// See Service.CreateRetry for retrying failed provisioning requests.
+func (s *Service) Create(ctx context.Context, identifier string, server CreateRequest, opts ...CreateOptions) (Server, error) {
+	if len(opts) > 0 && opts[0].ResolveUserData && server.UserData != "" {
+		id, err := s.ResolveUserDataID(ctx, server.UserData)
+		if err != nil {
+			return Server{}, err
 		}
-		return "", false
+		server.UserData = strconv.FormatInt(id, 10)
 	}
-
-	serverURL, err := s.PollProvisioning(ctx, pollURL, 5*time.Minute, identifier, isVPSReady)
-	if err != nil {
-		return Server{}, err
-	}
-
-	serverRes, err := s.BaseService.Get(ctx, serverURL)
-	if err != nil {
-		return Server{}, err
-	}
-
-	serverBody, err := s.Body(serverRes)
-	if err != nil {
-		return Server{}, fmt.Errorf("unexpected status %s", string(serverBody))
-	}
-
-	if serverRes.StatusCode != http.StatusOK {
-		return Server{}, fmt.Errorf("failed to fetch server info: %s", string(serverBody))
+	if len(opts) > 0 && opts[0].PickHost != nil && server.HostServer == "" {
+		if err := s.placeOnHost(ctx, &server, opts[0].PickHost); err != nil {
+			return Server{}, err
+		}
 	}
 
-	var created Server
-	err = json.Unmarshal(serverBody, &created)
+	job, err := s.CreateAsync(ctx, identifier, server)
 	if err != nil {
 		return Server{}, err
 	}
 
-	return created, nil
+	return job.Wait(ctx, opts...)
 }
 
 // UpdateSpecs represents updatable VPS specification fields.
@@ -225,15 +189,18 @@ func (s *UpdateSpecs) SetExtraRAM(v int64) { s.ExtraRAM = &v }
 
 // UpdateRequest represents the fields that can be updated for a VPS.
 type UpdateRequest struct {
-	Product    *string      `json:"product,omitempty"`
-	Specs      *UpdateSpecs `json:"specs,omitempty"`
-	Name       *string      `json:"name,omitempty"`
-	BootDevice *string      `json:"boot_device,omitempty"`
-	ISOImage   *string      `json:"iso_image,omitempty"`
-	CPUMode    *string      `json:"cpu_mode,omitempty"`
-	NetDevice  *string      `json:"net_device,omitempty"`
-	DiskBus    *string      `json:"disk_bus,omitempty"`
-	Tablet     *bool        `json:"tablet,omitempty"`
+	Product    *string        `json:"product,omitempty"`
+	Specs      *UpdateSpecs   `json:"specs,omitempty"`
+	Name       *string        `json:"name,omitempty"`
+	BootDevice *BootDevice    `json:"boot_device,omitempty"`
+	ISOImage   *string        `json:"iso_image,omitempty"`
+	CPUMode    *CPUMode       `json:"cpu_mode,omitempty"`
+	NetDevice  *NetDevice     `json:"net_device,omitempty"`
+	DiskBus    *DiskBus       `json:"disk_bus,omitempty"`
+	Tablet     *bool          `json:"tablet,omitempty"`
+	Dormant    *bool          `json:"dormant,omitempty"`
+	VNC        *VNCSettings   `json:"vnc,omitempty"`
+	Period     *ProductPeriod `json:"period,omitempty"`
 
 	// nullable fields with tri-state semantics for PATCH:
 	// unset (omit), set value, set null.
@@ -258,20 +225,29 @@ func (r *UpdateRequest) SetProduct(v string) { r.Product = &v }
 func (r *UpdateRequest) SetSpecs(v UpdateSpecs) { r.Specs = &v }
 
 // SetBootDevice sets the boot device.
-func (r *UpdateRequest) SetBootDevice(v string) { r.BootDevice = &v }
+func (r *UpdateRequest) SetBootDevice(v BootDevice) { r.BootDevice = &v }
 
 // SetCPUMode sets the CPU mode.
-func (r *UpdateRequest) SetCPUMode(v string) { r.CPUMode = &v }
+func (r *UpdateRequest) SetCPUMode(v CPUMode) { r.CPUMode = &v }
 
 // SetNetDevice sets the network device type.
-func (r *UpdateRequest) SetNetDevice(v string) { r.NetDevice = &v }
+func (r *UpdateRequest) SetNetDevice(v NetDevice) { r.NetDevice = &v }
 
 // SetDiskBus sets the disk bus type.
-func (r *UpdateRequest) SetDiskBus(v string) { r.DiskBus = &v }
+func (r *UpdateRequest) SetDiskBus(v DiskBus) { r.DiskBus = &v }
 
 // SetTablet sets tablet mode.
 func (r *UpdateRequest) SetTablet(v bool) { r.Tablet = &v }
 
+// SetDormant sets whether the VPS is dormant.
+func (r *UpdateRequest) SetDormant(v bool) { r.Dormant = &v }
+
+// SetPeriod sets the billing period.
+func (r *UpdateRequest) SetPeriod(v ProductPeriod) { r.Period = &v }
+
+// SetVNC sets the VNC mode and password.
+func (r *UpdateRequest) SetVNC(v VNCSettings) { r.VNC = &v }
+
 // SetName sets the VPS name (non-null).
 func (r *UpdateRequest) SetName(v string) {
 	r.Name = &v
@@ -333,6 +309,15 @@ func (r UpdateRequest) MarshalJSON() ([]byte, error) {
 	if r.Tablet != nil {
 		body["tablet"] = *r.Tablet
 	}
+	if r.Dormant != nil {
+		body["dormant"] = *r.Dormant
+	}
+	if r.VNC != nil {
+		body["vnc"] = r.VNC
+	}
+	if r.Period != nil {
+		body["period"] = *r.Period
+	}
 
 	switch {
 	case r.clearName:
@@ -352,24 +337,23 @@ func (r UpdateRequest) MarshalJSON() ([]byte, error) {
 }
 
 // RequiresPoweredOff reports whether this update includes fields that
-// the API requires the VPS to be powered off before changing.
+// the API requires the VPS to be powered off before changing, going by
+// DefaultUpdateCapabilities.
 func (r UpdateRequest) RequiresPoweredOff() bool {
-	return r.BootDevice != nil ||
-		r.ISOImage != nil ||
-		r.clearISOImage ||
-		r.CPUMode != nil ||
-		r.NetDevice != nil ||
-		r.DiskBus != nil ||
-		r.Tablet != nil
+	return DefaultUpdateCapabilities().RequiresPoweredOff(r)
 }
 
 // Update updates the settings for a provisioned VPS.
 //
-// Returns ErrEmptyIdentifier if the identifier is blank.
+// Returns ErrEmptyIdentifier if the identifier is blank and
+// ErrInvalidValue if a device or mode field is not valid.
 func (s *Service) Update(ctx context.Context, identifier string, req UpdateRequest) (UpdateResponse, error) {
 	if strings.TrimSpace(identifier) == "" {
 		return UpdateResponse{}, ErrEmptyIdentifier
 	}
+	if err := req.validateEnums(); err != nil {
+		return UpdateResponse{}, err
+	}
 
 	url := fmt.Sprintf("/vps/servers/%s", identifier)
 
@@ -382,13 +366,22 @@ func (s *Service) Update(ctx context.Context, identifier string, req UpdateReque
 }
 
 // Delete removes a provisioned VPS.
+// Only the first DeleteOptions is used.
 //
-// Returns ErrEmptyIdentifier if the identifier is blank.
+// Returns ErrEmptyIdentifier if the identifier is blank and
+// ErrProtected if it matches Service.Protected and is not forced.
 // Considers a 404 as a successful deletion.
-func (s *Service) Delete(ctx context.Context, identifier string) error {
+func (s *Service) Delete(ctx context.Context, identifier string, opts ...DeleteOptions) error {
 	if strings.TrimSpace(identifier) == "" {
 		return ErrEmptyIdentifier
 	}
+	var o DeleteOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if err := s.checkProtected(identifier, o); err != nil {
+		return err
+	}
 
 	url := fmt.Sprintf("/vps/servers/%s", identifier)
 
@@ -113,6 +113,33 @@ func TestGetImages_BadJSON(t *testing.T) {
 	}
 }
 
+func TestGetImages_Cached(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/images", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"debian":{"name":"debian-13","description":"Debian 13"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	c, _ := mythicbeasts.NewClient("", "", mythicbeasts.WithCache(mythicbeasts.NewMemoryCache(), time.Minute))
+	c.VPS().BaseURL = srv.URL
+
+	for range 2 {
+		images, err := c.VPS().GetImages(testContext())
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if images["debian"].Name != "debian-13" {
+			t.Fatalf("images = %+v", images)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("calls=%d, want 1", calls)
+	}
+}
+
 // Zones
 
 func TestGetZones_OK(t *testing.T) {
@@ -320,6 +347,49 @@ func TestGet_UnexpectedStatus(t *testing.T) {
 	}
 }
 
+func TestCreate_Conflict(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/taken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.VPS().Create(testContext(), "taken", vpsapi.CreateRequest{Product: "VPSX16"})
+	var conflict *vpsapi.ErrIdentifierConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("want ErrIdentifierConflict, got %T: %v", err, err)
+	}
+}
+
+func TestCreate_RetryFindsExisting(t *testing.T) {
+	t.Parallel()
+	posts := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/made", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			posts++
+			w.WriteHeader(http.StatusBadGateway)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"identifier":"made"}`))
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+	c.VPS().CreateRetry = vpsapi.RetryPolicy{Attempts: 3, Backoff: time.Millisecond}
+
+	_, err := c.VPS().Create(testContext(), "made", vpsapi.CreateRequest{Product: "VPSX16"})
+	var conflict *vpsapi.ErrIdentifierConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("want ErrIdentifierConflict, got %T: %v", err, err)
+	}
+	if posts != 1 {
+		t.Fatalf("posts=%d, want 1", posts)
+	}
+}
+
 func TestCreateRequest_Marshal_OmitsUnsetOptionalFields(t *testing.T) {
 	t.Parallel()
 
@@ -716,3 +786,33 @@ func TestSetPower_InvalidAction(t *testing.T) {
 		t.Fatalf("want invalid power action error, got %v", err)
 	}
 }
+
+func TestGetPowerStatus(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/on", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"on","status":"running"}`))
+	})
+	mux.HandleFunc("/vps/servers/off", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"off","status":"powered off"}`))
+	})
+	mux.HandleFunc("/vps/servers/parked", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"parked","status":"running","dormant":true}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	for id, want := range map[string]vpsapi.PowerState{
+		"on":     vpsapi.PowerStateOn,
+		"off":    vpsapi.PowerStateOff,
+		"parked": vpsapi.PowerStateOff,
+	} {
+		got, err := c.VPS().GetPowerStatus(testContext(), id)
+		if err != nil {
+			t.Fatalf("%s: err: %v", id, err)
+		}
+		if got != want {
+			t.Fatalf("%s: power=%q, want %q", id, got, want)
+		}
+	}
+}
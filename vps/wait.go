@@ -0,0 +1,113 @@
+package vps
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/wait"
+)
+
+const (
+	// DefaultWaitInterval is the default wait between polls in wait helpers.
+	DefaultWaitInterval = 10 * time.Second
+	// DefaultWaitTimeout is the default time limit of wait helpers.
+	DefaultWaitTimeout = 5 * time.Minute
+)
+
+// WaitOptions controls polling in the wait helpers.
+type WaitOptions struct {
+	// Interval is the wait between polls.
+	// If Interval <= 0, DefaultWaitInterval is used.
+	Interval time.Duration
+	// Timeout bounds the total wait.
+	// If Timeout <= 0, DefaultWaitTimeout is used.
+	Timeout time.Duration
+}
+
+// errWaitTimeout is returned by poll when the timeout is reached.
+var errWaitTimeout = wait.ErrTimeout
+
+// poll calls check until it reports done, returns an error,
+// or the timeout or context ends.
+func (o WaitOptions) poll(ctx context.Context, check func(ctx context.Context) (bool, error)) error {
+	return wait.Poll(ctx, o.interval(), o.timeout(), check)
+}
+
+// interval returns Interval, or DefaultWaitInterval if it is not set.
+func (o WaitOptions) interval() time.Duration {
+	if o.Interval <= 0 {
+		return DefaultWaitInterval
+	}
+	return o.Interval
+}
+
+// timeout returns Timeout, or DefaultWaitTimeout if it is not set.
+func (o WaitOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return DefaultWaitTimeout
+	}
+	return o.Timeout
+}
+
+// WaitForStatus polls the VPS until it reports the given status,
+// such as StatusRunning, StatusDormant or StatusPoweredOff, and returns the server.
+// Returns ErrWaitTimeout if the status is not reached in time.
+func (s *Service) WaitForStatus(ctx context.Context, identifier string, status Status, opts WaitOptions) (Server, error) {
+	return s.waitFor(ctx, identifier, string(status), opts, func(server Server) bool {
+		return server.Status == status
+	})
+}
+
+// WaitForPowerState polls the VPS until it reports the given power state
+// and returns the server.
+// Returns ErrWaitTimeout if the state is not reached in time.
+func (s *Service) WaitForPowerState(ctx context.Context, identifier string, state PowerState, opts WaitOptions) (Server, error) {
+	return s.waitFor(ctx, identifier, "power "+string(state), opts, func(server Server) bool {
+		return server.PowerState() == state
+	})
+}
+
+// DeleteAndWait removes the VPS and polls until it is no longer found,
+// so the identifier can be reused straight away.
+// Only the first DeleteOptions is used.
+// Returns ErrWaitTimeout if the VPS is still found when the wait times out.
+func (s *Service) DeleteAndWait(ctx context.Context, identifier string, opts WaitOptions, deleteOpts ...DeleteOptions) error {
+	if err := s.Delete(ctx, identifier, deleteOpts...); err != nil {
+		return err
+	}
+
+	var last string
+	err := opts.poll(ctx, func(ctx context.Context) (bool, error) {
+		server, err := s.Get(ctx, identifier)
+		if isNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		last = string(server.Status)
+		return false, nil
+	})
+	if errors.Is(err, errWaitTimeout) {
+		return &ErrWaitTimeout{Identifier: identifier, Want: "deleted", Last: last}
+	}
+
+	return err
+}
+
+// waitFor polls the VPS until match reports true.
+// want describes the awaited state in timeout errors.
+func (s *Service) waitFor(ctx context.Context, identifier string, want string, opts WaitOptions, match func(Server) bool) (Server, error) {
+	server, err := wait.For(ctx, opts.interval(), opts.timeout(), func(ctx context.Context) (Server, error) {
+		return s.Get(ctx, identifier)
+	}, match)
+	if errors.Is(err, errWaitTimeout) {
+		return server, &ErrWaitTimeout{Identifier: identifier, Want: want, Last: string(server.Status)}
+	}
+	if err != nil {
+		return Server{}, err
+	}
+
+	return server, nil
+}
@@ -0,0 +1,140 @@
+package vps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestWaitForStatus(t *testing.T) {
+	t.Parallel()
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		status := "powered off"
+		if gets >= 3 {
+			status = "running"
+		}
+		_, _ = fmt.Fprintf(w, `{"identifier":"box","status":%q}`, status)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	server, err := c.VPS().WaitForStatus(testContext(), "box", "running", vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if server.Status != "running" || gets != 3 {
+		t.Fatalf("status=%q gets=%d", server.Status, gets)
+	}
+}
+
+func TestWaitForStatus_Timeout(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"box","status":"powered off"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.VPS().WaitForStatus(testContext(), "box", "running", vpsapi.WaitOptions{Interval: 5 * time.Millisecond, Timeout: 20 * time.Millisecond})
+	var timeout *vpsapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+	if timeout.Last != "powered off" || timeout.Want != "running" {
+		t.Fatalf("timeout=%+v", timeout)
+	}
+}
+
+func TestPowerCycle(t *testing.T) {
+	t.Parallel()
+	var actions []string
+	status := "running"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box/power", func(w http.ResponseWriter, r *http.Request) {
+		var req vpsapi.PowerRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		actions = append(actions, string(req.Power))
+		switch req.Power {
+		case vpsapi.PowerActionShutdown:
+			status = "powered off"
+		case vpsapi.PowerActionOn:
+			status = "running"
+		}
+		_, _ = w.Write([]byte(`{"message":"ok"}`))
+	})
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"identifier":"box","status":%q}`, status)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	_, err := c.VPS().PowerCycle(testContext(), "box", vpsapi.PowerCycleOptions{
+		Wait:           vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second},
+		Shutdown:       true,
+		WaitForRunning: true,
+	})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if len(actions) != 2 || actions[0] != "shutdown" || actions[1] != "power-on" {
+		t.Fatalf("actions=%v", actions)
+	}
+}
+
+func TestDeleteAndWait(t *testing.T) {
+	t.Parallel()
+	gets := 0
+	deleted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			deleted = true
+			w.WriteHeader(http.StatusAccepted)
+		case http.MethodGet:
+			gets++
+			if gets >= 3 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"identifier":"box","status":"deleting"}`))
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	err := c.VPS().DeleteAndWait(testContext(), "box", vpsapi.WaitOptions{Interval: time.Millisecond, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if !deleted || gets != 3 {
+		t.Fatalf("deleted=%v gets=%d", deleted, gets)
+	}
+}
+
+func TestDeleteAndWait_Timeout(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"identifier":"box","status":"deleting"}`))
+		}
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	err := c.VPS().DeleteAndWait(testContext(), "box", vpsapi.WaitOptions{Interval: 5 * time.Millisecond, Timeout: 20 * time.Millisecond})
+	var timeout *vpsapi.ErrWaitTimeout
+	if !errors.As(err, &timeout) || timeout.Want != "deleted" || timeout.Last != "deleting" {
+		t.Fatalf("err=%v, want ErrWaitTimeout", err)
+	}
+}
@@ -0,0 +1,68 @@
+package vps
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// WatchEvent is a change to a watched VPS.
+type WatchEvent struct {
+	// Server is the VPS as retrieved. It is empty if Err is set.
+	Server Server
+	// Err is why the VPS could not be retrieved.
+	Err error
+}
+
+// Watch polls the VPS every interval and yields an event with a
+// snapshot of the server first and then whenever its status, product
+// or specs change.
+// If interval <= 0, DefaultWaitInterval is used.
+//
+// A failed poll is yielded as an event with Err set and polling
+// continues; stop ranging over the sequence, or cancel ctx, to stop.
+// The sequence ends when ctx is done.
+func (s *Service) Watch(ctx context.Context, identifier string, interval time.Duration) iter.Seq[WatchEvent] {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	return func(yield func(WatchEvent) bool) {
+		var (
+			last Server
+			seen bool
+		)
+		for {
+			server, err := s.Get(ctx, identifier)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if !yield(WatchEvent{Err: err}) {
+					return
+				}
+			case !seen || changed(last, server):
+				last, seen = server, true
+				if !yield(WatchEvent{Server: server}) {
+					return
+				}
+			}
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// changed reports whether the watched fields differ between a and b.
+func changed(a, b Server) bool {
+	return a.Status != b.Status ||
+		a.Dormant != b.Dormant ||
+		a.Product != b.Product ||
+		a.Specs != b.Specs
+}
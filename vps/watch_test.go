@@ -0,0 +1,62 @@
+package vps_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+	statuses := []string{"provisioning", "provisioning", "running", "running", "powered off"}
+	gets := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(gets, len(statuses)-1)]
+		gets++
+		_, _ = fmt.Fprintf(w, `{"identifier":"box","status":%q}`, status)
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(testContext(), 5*time.Second)
+	defer cancel()
+
+	var seen []string
+	for event := range c.VPS().Watch(ctx, "box", time.Millisecond) {
+		if event.Err != nil {
+			t.Fatalf("err: %v", event.Err)
+		}
+		seen = append(seen, string(event.Server.Status))
+		if event.Server.Status == "powered off" {
+			break
+		}
+	}
+
+	if fmt.Sprint(seen) != "[provisioning running powered off]" {
+		t.Fatalf("seen=%v", seen)
+	}
+}
+
+func TestWatch_StopsOnCancel(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vps/servers/box", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"identifier":"box","status":"running"}`))
+	})
+	c, srv := newTestClient(t, mux)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(testContext())
+	defer cancel()
+	snapshots := 0
+	for range c.VPS().Watch(ctx, "box", time.Millisecond) {
+		snapshots++
+		cancel()
+	}
+	if snapshots != 1 {
+		t.Fatalf("snapshots=%d, want 1", snapshots)
+	}
+}
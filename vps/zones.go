@@ -1,6 +1,11 @@
 package vps
 
-import "context"
+import (
+	"context"
+	"slices"
+
+	"github.com/paultibbetts/mythicbeasts-client-go/internal/transport"
+)
 
 // Zone represents a zone (datacentre) a VPS may be
 // provisioned in. It can include its parent zones.
@@ -17,9 +22,80 @@ type Zones map[string]Zone
 // a VPS may be provisioned in.
 func (s *Service) GetZones(ctx context.Context) (Zones, error) {
 	var result Zones
-	if _, _, err := s.GetJSON(ctx, "/vps/zones", &result); err != nil {
+	if _, _, err := s.GetJSON(transport.Cacheable(ctx), "/vps/zones", &result); err != nil {
 		return nil, err
 	}
 
 	return result, nil
 }
+
+// ZoneTree answers questions about the zone hierarchy,
+// such as whether one zone is inside another.
+type ZoneTree struct {
+	parents  map[string][]string
+	children map[string][]string
+}
+
+// NewZoneTree builds a ZoneTree from zones.
+func NewZoneTree(zones Zones) *ZoneTree {
+	t := &ZoneTree{
+		parents:  make(map[string][]string, len(zones)),
+		children: make(map[string][]string),
+	}
+	for name, zone := range zones {
+		if zone.Name != "" {
+			name = zone.Name
+		}
+		t.parents[name] = zone.Parents
+		for _, parent := range zone.Parents {
+			t.children[parent] = append(t.children[parent], name)
+		}
+	}
+	return t
+}
+
+// GetZoneTree retrieves the available zones and builds a ZoneTree.
+func (s *Service) GetZoneTree(ctx context.Context) (*ZoneTree, error) {
+	zones, err := s.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewZoneTree(zones), nil
+}
+
+// Ancestors returns every zone that contains the zone, sorted by name.
+func (t *ZoneTree) Ancestors(zone string) []string {
+	return walk(zone, t.parents)
+}
+
+// Descendants returns every zone contained in the zone, sorted by name.
+func (t *ZoneTree) Descendants(zone string) []string {
+	return walk(zone, t.children)
+}
+
+// IsWithin reports whether zone is ancestor or is contained in it.
+func (t *ZoneTree) IsWithin(zone, ancestor string) bool {
+	if zone == ancestor {
+		return true
+	}
+	return slices.Contains(t.Ancestors(zone), ancestor)
+}
+
+// walk returns the zones reachable from zone through edges.
+func walk(zone string, edges map[string][]string) []string {
+	seen := map[string]bool{zone: true}
+	queue := slices.Clone(edges[zone])
+	var result []string
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		result = append(result, next)
+		queue = append(queue, edges[next]...)
+	}
+	slices.Sort(result)
+	return result
+}
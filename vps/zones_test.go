@@ -0,0 +1,33 @@
+package vps_test
+
+import (
+	"fmt"
+	"testing"
+
+	vpsapi "github.com/paultibbetts/mythicbeasts-client-go/vps"
+)
+
+func TestZoneTree(t *testing.T) {
+	t.Parallel()
+	tree := vpsapi.NewZoneTree(vpsapi.Zones{
+		"eu":   {Name: "eu"},
+		"uk":   {Name: "uk", Parents: []string{"eu"}},
+		"lon":  {Name: "lon", Parents: []string{"uk"}},
+		"lon1": {Name: "lon1", Parents: []string{"lon"}},
+		"lon2": {Name: "lon2", Parents: []string{"lon"}},
+		"ams":  {Name: "ams", Parents: []string{"eu"}},
+	})
+
+	if got := fmt.Sprint(tree.Ancestors("lon1")); got != "[eu lon uk]" {
+		t.Fatalf("ancestors=%s, want [eu lon uk]", got)
+	}
+	if got := fmt.Sprint(tree.Descendants("uk")); got != "[lon lon1 lon2]" {
+		t.Fatalf("descendants=%s, want [lon lon1 lon2]", got)
+	}
+	if !tree.IsWithin("lon1", "eu") || !tree.IsWithin("lon1", "lon1") {
+		t.Fatalf("lon1 should be within eu and itself")
+	}
+	if tree.IsWithin("ams", "uk") {
+		t.Fatalf("ams should not be within uk")
+	}
+}